@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unchanged lines shown around the changes of a hunk
+const diffContext = 3
+
+// bound of the line matching table, bigger changes are shown as a
+// replacement of every line
+const maxDiffCells = 1 << 24
+
+// diffLine is a line of a diff: ' ' kept, '-' removed or '+' added
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// unifiedDiff returns the changes from a to b in the unified format, empty
+// when they are equal
+func unifiedDiff(fromName, toName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %v\n+++ %v\n", fromName, toName)
+	// aPos and bPos count the lines of a and b before every line of the diff
+	aPos := make([]int, len(lines)+1)
+	bPos := make([]int, len(lines)+1)
+	for i, l := range lines {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if l.kind != '+' {
+			aPos[i+1]++
+		}
+		if l.kind != '-' {
+			bPos[i+1]++
+		}
+	}
+
+	written := 0
+	for i := 0; i < len(lines); {
+		if lines[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := i - diffContext
+		if start < written {
+			start = written
+		}
+		// changes closer than twice the context share a hunk
+		end := i
+		for end < len(lines) {
+			if lines[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].kind == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		end += diffContext
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		fmt.Fprintf(&out, "@@ -%v +%v @@\n",
+			hunkRange(aPos[start], aPos[end]-aPos[start]), hunkRange(bPos[start], bPos[end]-bPos[start]))
+		for _, l := range lines[start:end] {
+			out.WriteByte(l.kind)
+			out.WriteString(l.text)
+			out.WriteByte('\n')
+		}
+		written, i = end, end
+	}
+	return out.String()
+}
+
+// hunkRange formats the lines of a hunk side, starting after skipped lines
+func hunkRange(skipped, count int) string {
+	if count == 0 {
+		// the line before the hunk
+		return fmt.Sprintf("%v,0", skipped)
+	}
+	if count == 1 {
+		return fmt.Sprint(skipped + 1)
+	}
+	return fmt.Sprintf("%v,%v", skipped+1, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines matches the lines of a and b with a longest common subsequence
+func diffLines(a, b []string) []diffLine {
+	// the common start and end need no matching
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var lines []diffLine
+	for _, text := range a[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	lines = append(lines, matchLines(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+	return lines
+}
+
+func matchLines(a, b []string) []diffLine {
+	var lines []diffLine
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, text := range a {
+			lines = append(lines, diffLine{'-', text})
+		}
+		for _, text := range b {
+			lines = append(lines, diffLine{'+', text})
+		}
+		return lines
+	}
+
+	// common[i*w+j] is the length of the longest common subsequence of a[i:] and b[j:]
+	w := len(b) + 1
+	common := make([]int32, (len(a)+1)*w)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				common[i*w+j] = common[(i+1)*w+j+1] + 1
+			case common[(i+1)*w+j] >= common[i*w+j+1]:
+				common[i*w+j] = common[(i+1)*w+j]
+			default:
+				common[i*w+j] = common[i*w+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case common[(i+1)*w+j] >= common[i*w+j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// blogs read from the storage at once by ExportBlogs
+const exportBatch = 100
+
+func (s *server) ExportBlogs(req *blogpb.ExportBlogsRequest, stream blogpb.BlogService_ExportBlogsServer) error {
+	fmt.Printf("ExportBlogs function was invoked with %v\n", req)
+
+	q := &listQuery{
+		AuthorID:    req.GetAuthorId(),
+		Tag:         normalizeTag(req.GetTag()),
+		Status:      statusOf(req.GetStatus()),
+		OldestFirst: true,
+		Limit:       exportBatch,
+	}
+	var err error
+	if req.GetCreatedAfter() != nil {
+		if q.CreatedAfter, err = ptypes.Timestamp(req.GetCreatedAfter()); err != nil {
+			return invalidField("created_after", err.Error())
+		}
+	}
+	if req.GetCreatedBefore() != nil {
+		if q.CreatedBefore, err = ptypes.Timestamp(req.GetCreatedBefore()); err != nil {
+			return invalidField("created_before", err.Error())
+		}
+	}
+
+	for {
+		blogs, err := s.blogs.List(stream.Context(), q)
+		if err != nil {
+			log.Printf("Error while exporting blogs: %v", err)
+			return storageError("Cannot list the blogs of the storage")
+		}
+		for _, blog := range blogs {
+			if err := stream.Send(&blogpb.ExportBlogsResponse{Blog: blog.toProto()}); err != nil {
+				// the client went away
+				return err
+			}
+		}
+		if len(blogs) < exportBatch {
+			return nil
+		}
+		after := q.cursor(blogs[len(blogs)-1])
+		q.After = &after
+	}
+}
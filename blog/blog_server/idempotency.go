@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadata key of the idempotency key, same as the idempotency_key field
+const idempotencyKeyHeader = "idempotency-key"
+
+// a key without a response after this long belongs to a CreateBlog that died
+// half way, the key can be claimed again
+const idempotencyPendingTimeout = 30 * time.Second
+
+// idempotencyRecord remembers the CreateBlog sent with a key
+type idempotencyRecord struct {
+	Key         string             `bson:"_id"`
+	RequestHash string             `bson:"request_hash"`
+	BlogID      primitive.ObjectID `bson:"blog_id"`
+	Response    []byte             `bson:"response"` // empty while the blog is being created
+	CreatedAt   time.Time          `bson:"created_at"`
+}
+
+// idempotencyKey reads the key from the request or from the metadata
+func idempotencyKey(ctx context.Context, req *blogpb.CreateBlogRequest) (string, error) {
+	key := req.GetIdempotencyKey()
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+		if key != "" && key != values[0] {
+			return "", status.Error(
+				codes.InvalidArgument,
+				"The idempotency key of the request and of the metadata are different",
+			)
+		}
+		key = values[0]
+	}
+	return key, nil
+}
+
+// requestHash tells apart two blogs sent with the same key
+func requestHash(blog *blogpb.Blog) (string, error) {
+	b := proto.NewBuffer(nil)
+	b.SetDeterministic(true)
+	if err := b.Marshal(blog); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createBlogIdempotent creates the blog once per key, replays get the
+// response of the first call
+func (s *server) createBlogIdempotent(ctx context.Context, key string, blog *blogpb.Blog) (*blogpb.CreateBlogResponse, error) {
+	hash, err := requestHash(blog)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
+	}
+
+	for {
+		record := &idempotencyRecord{
+			Key:         key,
+			RequestHash: hash,
+			BlogID:      primitive.NewObjectID(),
+			// MongoDB keeps milliseconds, Release compares it
+			CreatedAt: s.now(),
+		}
+		existing, err := s.keys.Claim(ctx, record)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
+		}
+		if existing == nil {
+			return s.createClaimedBlog(ctx, record, blog)
+		}
+
+		age := s.clock.Now().Sub(existing.CreatedAt)
+		abandoned := len(existing.Response) == 0 && age > idempotencyPendingTimeout
+		if abandoned && existing.RequestHash == hash {
+			// the blog may have been created without storing the response
+			data, err := s.blogs.Get(ctx, existing.BlogID)
+			if err == nil {
+				return &blogpb.CreateBlogResponse{Blog: data.toProto()}, nil
+			}
+			if err != errBlogNotFound {
+				return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
+			}
+		}
+		if age > *idempotencyTTL || abandoned {
+			// expired keys are only removed about once a minute, do not wait for it
+			if err := s.keys.Release(ctx, key, existing.CreatedAt); err != nil {
+				return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
+			}
+			continue
+		}
+
+		if existing.RequestHash != hash {
+			return nil, errorWithDetails(
+				status.New(codes.AlreadyExists, fmt.Sprintf("The idempotency key %v was used for a different blog", key)),
+				&errdetails.ErrorInfo{
+					Reason:   "IDEMPOTENCY_KEY_REUSED",
+					Domain:   errorDomain,
+					Metadata: map[string]string{"idempotency_key": key, "blog_id": existing.BlogID.Hex()},
+				},
+			)
+		}
+		if len(existing.Response) == 0 {
+			return nil, errorWithDetails(
+				status.New(codes.Unavailable, fmt.Sprintf("The blog of idempotency key %v is still being created", key)),
+				&errdetails.RetryInfo{
+					RetryDelay: ptypes.DurationProto(storageRetryDelay),
+				},
+			)
+		}
+
+		res := &blogpb.CreateBlogResponse{}
+		if err := proto.Unmarshal(existing.Response, res); err != nil {
+			return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
+		}
+		fmt.Printf("Replaying CreateBlog of idempotency key %v\n", key)
+		return res, nil
+	}
+}
+
+// createClaimedBlog creates the blog of a key just claimed and stores the response
+func (s *server) createClaimedBlog(ctx context.Context, record *idempotencyRecord, blog *blogpb.Blog) (*blogpb.CreateBlogResponse, error) {
+	res, err := s.insertBlog(ctx, record.BlogID, blog)
+	if err != nil {
+		// let the client retry with the same key
+		if err := s.keys.Release(ctx, record.Key, record.CreatedAt); err != nil {
+			log.Printf("Cannot release idempotency key %v: %v", record.Key, err)
+		}
+		return nil, err
+	}
+
+	data, err := proto.Marshal(res)
+	if err == nil {
+		err = s.keys.SetResponse(ctx, record.Key, data)
+	}
+	if err != nil {
+		// the blog exists, a replay finds it once the key is abandoned
+		log.Printf("Cannot store the response of idempotency key %v: %v", record.Key, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func createWithKey(srv *server, key, title string) (*blogpb.Blog, error) {
+	res, err := srv.CreateBlog(context.Background(), &blogpb.CreateBlogRequest{
+		Blog:           &blogpb.Blog{AuthorId: "author", Title: title},
+		IdempotencyKey: key,
+	})
+	return res.GetBlog(), err
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	c := newFakeClock()
+	srv := newTestServer(newMemoryStore(*idempotencyTTL), c)
+
+	first, err := createWithKey(srv, "key", "first")
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	c.advance(time.Hour)
+	replay, err := createWithKey(srv, "key", "first")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replay.GetId() != first.GetId() {
+		t.Errorf("replay created blog %v, want %v", replay.GetId(), first.GetId())
+	}
+	if _, err := createWithKey(srv, "key", "other"); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("other blog with the same key: code = %v, want %v", status.Code(err), codes.AlreadyExists)
+	}
+
+	// the key is forgotten after its TTL
+	c.advance(*idempotencyTTL)
+	again, err := createWithKey(srv, "key", "first")
+	if err != nil {
+		t.Fatalf("CreateBlog after the TTL: %v", err)
+	}
+	if again.GetId() == first.GetId() {
+		t.Errorf("CreateBlog after the TTL replayed blog %v", first.GetId())
+	}
+}
+
+func TestIdempotentAbandonedKey(t *testing.T) {
+	tests := []struct {
+		name string
+		// the blog was created before the server stopped, without its response
+		created bool
+	}{
+		{"blog not created", false},
+		{"blog created", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClock()
+			store := newMemoryStore(*idempotencyTTL)
+			srv := newTestServer(store, c)
+			blog := &blogpb.Blog{AuthorId: "author", Title: "abandoned"}
+			hash, err := requestHash(blog)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// a server claimed the key and stopped before storing the response
+			record := &idempotencyRecord{
+				Key:         "key",
+				RequestHash: hash,
+				BlogID:      primitive.NewObjectID(),
+				CreatedAt:   srv.now(),
+			}
+			if _, err := store.Claim(context.Background(), record); err != nil {
+				t.Fatal(err)
+			}
+			if tt.created {
+				if _, err := srv.insertBlog(context.Background(), record.BlogID, blog); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c.advance(idempotencyPendingTimeout)
+			_, err = createWithKey(srv, "key", "abandoned")
+			if status.Code(err) != codes.Unavailable {
+				t.Fatalf("code while the blog may still be created = %v, want %v", status.Code(err), codes.Unavailable)
+			}
+
+			c.advance(time.Millisecond)
+			created, err := createWithKey(srv, "key", "abandoned")
+			if err != nil {
+				t.Fatalf("CreateBlog once the key was abandoned: %v", err)
+			}
+			if got := created.GetId() == record.BlogID.Hex(); got != tt.created {
+				t.Errorf("got the blog of the abandoned key = %v, want %v", got, tt.created)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/FernandoDevBh/grpc-go-course/validation"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blogs inserted together by ImportBlogs
+const importBatch = 100
+
+// editor of the revisions made by ImportBlogs
+const importEditor = "import"
+
+func (s *server) ImportBlogs(stream blogpb.BlogService_ImportBlogsServer) error {
+	fmt.Printf("ImportBlogs function was invoked with streaming request: %v\n", stream)
+
+	im := &importer{
+		srv:     s,
+		ctx:     stream.Context(),
+		res:     &blogpb.ImportBlogsResponse{},
+		batched: make(map[string]bool),
+		planned: make(map[string]bool),
+	}
+	for index := 0; ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error while reading client stream: %v", err)
+			return err
+		}
+		if index == 0 {
+			im.dryRun = req.GetDryRun()
+			im.upsert = req.GetUpsert()
+		}
+		im.add(index, req)
+	}
+	im.flush()
+
+	for _, result := range im.res.Results {
+		switch result.Action {
+		case blogpb.ImportBlogsResult_CREATED:
+			im.res.Created++
+		case blogpb.ImportBlogsResult_UPDATED:
+			im.res.Updated++
+		case blogpb.ImportBlogsResult_FAILED:
+			im.res.Failed++
+		}
+	}
+	return stream.SendAndClose(im.res)
+}
+
+// importer keeps the state of an ImportBlogs stream, the new blogs are
+// inserted by batches
+type importer struct {
+	srv    *server
+	ctx    context.Context
+	dryRun bool
+	upsert bool
+	res    *blogpb.ImportBlogsResponse
+
+	// the blogs of the next batch with their results, and their external IDs
+	batch   []*blogItem
+	results []*blogpb.ImportBlogsResult
+	batched map[string]bool
+	// external IDs a dry run would have created
+	planned map[string]bool
+}
+
+// add imports the blog of req, or adds it to the batch
+func (im *importer) add(index int, req *blogpb.ImportBlogsRequest) {
+	result := &blogpb.ImportBlogsResult{
+		Index:      int32(index),
+		ExternalId: req.GetExternalId(),
+	}
+	im.res.Results = append(im.res.Results, result)
+	if err := validation.Check(req); err != nil {
+		failImport(result, err)
+		return
+	}
+	data, err := im.srv.importedBlog(req)
+	if err != nil {
+		failImport(result, err)
+		return
+	}
+
+	if id := data.ExternalID; id != "" {
+		if im.batched[id] {
+			// imported twice by the stream, the first one is inserted before
+			im.flush()
+		}
+		existing, err := im.srv.blogs.GetByExternalID(im.ctx, id)
+		if err != nil && err != errBlogNotFound {
+			log.Printf("Error while reading the blog imported with external ID %q: %v", id, err)
+			failImport(result, storageError("Cannot read blog from the storage"))
+			return
+		}
+		if err == nil || im.planned[id] {
+			if !im.upsert {
+				failImport(result, externalIDExists(id))
+				return
+			}
+			im.update(result, existing, data)
+			return
+		}
+	}
+	im.create(result, data)
+}
+
+// create adds data to the batch, the batch is inserted when full
+func (im *importer) create(result *blogpb.ImportBlogsResult, data *blogItem) {
+	if im.dryRun {
+		result.Action = blogpb.ImportBlogsResult_CREATED
+		if data.ExternalID != "" {
+			im.planned[data.ExternalID] = true
+		}
+		return
+	}
+	data.ID = primitive.NewObjectID()
+	im.batch = append(im.batch, data)
+	im.results = append(im.results, result)
+	if data.ExternalID != "" {
+		im.batched[data.ExternalID] = true
+	}
+	if len(im.batch) == importBatch {
+		im.flush()
+	}
+}
+
+// update records a revision of existing with the content of data, existing
+// is nil when a dry run would have created it
+func (im *importer) update(result *blogpb.ImportBlogsResult, existing, data *blogItem) {
+	if existing != nil && existing.deleted() {
+		failImport(result, errorWithDetails(
+			status.New(codes.FailedPrecondition, fmt.Sprintf("Blog %v is in the trash, restore it first", existing.ID.Hex())),
+			&errdetails.ErrorInfo{
+				Reason:   "BLOG_IN_TRASH",
+				Domain:   errorDomain,
+				Metadata: map[string]string{"blog_id": existing.ID.Hex(), "external_id": data.ExternalID},
+			},
+		))
+		return
+	}
+	if im.dryRun {
+		result.Action = blogpb.ImportBlogsResult_UPDATED
+		if existing != nil {
+			result.BlogId = existing.ID.Hex()
+		}
+		return
+	}
+
+	updated, _, err := im.srv.reviseBlog(im.ctx, existing.ID, "", func(current *blogItem) (*revisionItem, error) {
+		// the blog keeps its creation time
+		rev := newRevision(current, importEditor)
+		rev.AuthorID = data.AuthorID
+		rev.Title = data.Title
+		rev.Content = data.Content
+		rev.Tags = data.Tags
+		rev.Status = data.Status
+		rev.PublishAt = data.PublishAt
+		rev.PublishedAt = data.PublishedAt
+		return rev, nil
+	})
+	if err != nil {
+		failImport(result, reviseError(existing.ID.Hex(), err))
+		return
+	}
+	result.Action = blogpb.ImportBlogsResult_UPDATED
+	result.BlogId = updated.ID.Hex()
+}
+
+// flush inserts the batch, the blogs that fail do not stop the others
+func (im *importer) flush() {
+	if len(im.batch) == 0 {
+		return
+	}
+	blogs, results := im.batch, im.results
+	im.batch, im.results, im.batched = nil, nil, make(map[string]bool)
+
+	// the revisions first, a blog always has its current revision
+	revs := make([]*revisionItem, len(blogs))
+	for i, blog := range blogs {
+		revs[i] = newRevision(blog, importEditor)
+	}
+	if err := im.srv.revisions.AddRevisions(im.ctx, revs); err != nil {
+		log.Printf("Error while importing %v blogs: %v", len(blogs), err)
+		for _, result := range results {
+			failImport(result, storageError("Cannot write the blog to the storage"))
+		}
+		return
+	}
+	errs, err := im.srv.blogs.InsertMany(im.ctx, blogs)
+	if err != nil {
+		errs = make([]error, len(blogs))
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+
+	for i, blog := range blogs {
+		switch errs[i] {
+		case nil:
+			results[i].Action = blogpb.ImportBlogsResult_CREATED
+			results[i].BlogId = blog.ID.Hex()
+			continue
+		case errExternalIDExists:
+			// imported by another client since it was looked up
+			failImport(results[i], externalIDExists(blog.ExternalID))
+		default:
+			log.Printf("Error while importing blog %v: %v", blog.ID.Hex(), errs[i])
+			failImport(results[i], storageError("Cannot write the blog to the storage"))
+		}
+		// the blog was not inserted, its revision goes
+		if err := im.srv.revisions.DeleteRevisions(im.ctx, blog.ID); err != nil {
+			log.Printf("Error while deleting the revisions of blog %v: %v", blog.ID.Hex(), err)
+		}
+	}
+}
+
+// importedBlog is the blog to create for req, without an ID
+func (s *server) importedBlog(req *blogpb.ImportBlogsRequest) (*blogItem, error) {
+	blog := req.GetBlog()
+	tags, err := normalizeTags(blog.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	data := &blogItem{
+		AuthorID:   blog.GetAuthorId(),
+		Title:      blog.GetTitle(),
+		Content:    blog.GetContent(),
+		Tags:       tags,
+		CreatedAt:  now,
+		Revision:   1,
+		Status:     statusOf(blog.GetStatus()),
+		ExternalID: req.GetExternalId(),
+	}
+	times := []struct {
+		field string
+		ts    *timestamp.Timestamp
+		t     *time.Time
+	}{
+		{"blog.created_at", blog.GetCreatedAt(), &data.CreatedAt},
+		{"blog.updated_at", blog.GetUpdatedAt(), &data.UpdatedAt},
+		{"blog.publish_at", blog.GetPublishAt(), &data.PublishAt},
+		{"blog.published_at", blog.GetPublishedAt(), &data.PublishedAt},
+	}
+	for _, f := range times {
+		if f.ts == nil {
+			continue
+		}
+		t, err := ptypes.Timestamp(f.ts)
+		if err != nil {
+			return nil, invalidField(f.field, err.Error())
+		}
+		*f.t = t.UTC().Truncate(time.Millisecond)
+	}
+	if data.UpdatedAt.IsZero() {
+		data.UpdatedAt = data.CreatedAt
+	}
+
+	switch data.Status {
+	case statusScheduled:
+		if data.PublishAt.IsZero() {
+			return nil, invalidField("blog.publish_at", "A SCHEDULED blog needs a publication time")
+		}
+	case statusDraft:
+		data.PublishAt = time.Time{}
+	default:
+		// published when not set, like a new blog
+		if data.Status == "" {
+			data.Status = statusPublished
+		}
+		data.PublishAt = time.Time{}
+		if data.PublishedAt.IsZero() {
+			data.PublishedAt = data.CreatedAt
+		}
+	}
+	return data, nil
+}
+
+// failImport records err as the outcome of result
+func failImport(result *blogpb.ImportBlogsResult, err error) {
+	st := status.Convert(err)
+	result.Action = blogpb.ImportBlogsResult_FAILED
+	result.BlogId = ""
+	result.ErrorCode = st.Code().String()
+	result.ErrorMessage = st.Message()
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			result.ErrorReason = info.GetReason()
+		}
+	}
+}
+
+// externalIDExists tells that a blog was already imported with the external ID
+func externalIDExists(externalID string) error {
+	return errorWithDetails(
+		status.New(codes.AlreadyExists, fmt.Sprintf("A blog was already imported with the external ID %q", externalID)),
+		&errdetails.ErrorInfo{
+			Reason:   "EXTERNAL_ID_EXISTS",
+			Domain:   errorDomain,
+			Metadata: map[string]string{"external_id": externalID},
+		},
+	)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestImportedBlogStatus(t *testing.T) {
+	c := newFakeClock()
+	srv := newTestServer(newMemoryStore(time.Hour), c)
+	now := c.Now()
+	created := now.Add(-24 * time.Hour)
+	published := now.Add(-time.Hour)
+	publishAt := now.Add(time.Hour)
+	ts := func(t time.Time) *timestamp.Timestamp {
+		p, _ := ptypes.TimestampProto(t)
+		return p
+	}
+
+	tests := []struct {
+		name            string
+		blog            *blogpb.Blog
+		wantCode        codes.Code
+		wantStatus      string
+		wantPublishedAt time.Time
+		wantPublishAt   time.Time
+	}{
+		{
+			// like CreateBlog
+			name:            "no status",
+			blog:            &blogpb.Blog{},
+			wantStatus:      statusPublished,
+			wantPublishedAt: now,
+		},
+		{
+			name:            "no status with a creation time",
+			blog:            &blogpb.Blog{CreatedAt: ts(created)},
+			wantStatus:      statusPublished,
+			wantPublishedAt: created,
+		},
+		{
+			name:            "published",
+			blog:            &blogpb.Blog{Status: blogpb.Blog_PUBLISHED, CreatedAt: ts(created), PublishedAt: ts(published)},
+			wantStatus:      statusPublished,
+			wantPublishedAt: published,
+		},
+		{
+			name:            "archived",
+			blog:            &blogpb.Blog{Status: blogpb.Blog_ARCHIVED, CreatedAt: ts(created)},
+			wantStatus:      statusArchived,
+			wantPublishedAt: created,
+		},
+		{
+			name:       "draft",
+			blog:       &blogpb.Blog{Status: blogpb.Blog_DRAFT, CreatedAt: ts(created), PublishAt: ts(publishAt)},
+			wantStatus: statusDraft,
+		},
+		{
+			name:          "scheduled",
+			blog:          &blogpb.Blog{Status: blogpb.Blog_SCHEDULED, PublishAt: ts(publishAt)},
+			wantStatus:    statusScheduled,
+			wantPublishAt: publishAt,
+		},
+		{
+			name:     "scheduled without a publication time",
+			blog:     &blogpb.Blog{Status: blogpb.Blog_SCHEDULED},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.blog.AuthorId = "author"
+			tt.blog.Title = tt.name
+			data, err := srv.importedBlog(&blogpb.ImportBlogsRequest{Blog: tt.blog})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v: %v", code, tt.wantCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if data.Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v", data.Status, tt.wantStatus)
+			}
+			if !data.PublishedAt.Equal(tt.wantPublishedAt) {
+				t.Errorf("published at %v, want %v", data.PublishedAt, tt.wantPublishedAt)
+			}
+			if !data.PublishAt.Equal(tt.wantPublishAt) {
+				t.Errorf("publish at %v, want %v", data.PublishAt, tt.wantPublishAt)
+			}
+		})
+	}
+}
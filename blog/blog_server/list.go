@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blogs of a ListBlogs page when the request has no page size
+const defaultPageSize = 20
+
+// tags limits, tags are kept in lower case
+const (
+	maxTags      = 20
+	maxTagLength = 50
+)
+
+// pageToken is the last blog of a page, the next page starts after it
+type pageToken struct {
+	At int64  `json:"t"` // unix milliseconds
+	ID string `json:"id"`
+	// Query is a hash of the filters and order of the request of the page
+	Query string `json:"q"`
+}
+
+func (s *server) ListBlogs(ctx context.Context, req *blogpb.ListBlogsRequest) (*blogpb.ListBlogsResponse, error) {
+	fmt.Printf("ListBlogs function was invoked with %v\n", req)
+
+	q := &listQuery{
+		AuthorID:    req.GetAuthorId(),
+		Tag:         normalizeTag(req.GetTag()),
+		Status:      statusOf(req.GetStatus()),
+		OldestFirst: req.GetOrder() == blogpb.ListBlogsRequest_OLDEST_FIRST,
+		Limit:       int(req.GetPageSize()),
+	}
+	if q.Status == "" {
+		q.Status = statusPublished
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultPageSize
+	}
+	var err error
+	if req.GetCreatedAfter() != nil {
+		if q.CreatedAfter, err = ptypes.Timestamp(req.GetCreatedAfter()); err != nil {
+			return nil, invalidField("created_after", err.Error())
+		}
+	}
+	if req.GetCreatedBefore() != nil {
+		if q.CreatedBefore, err = ptypes.Timestamp(req.GetCreatedBefore()); err != nil {
+			return nil, invalidField("created_before", err.Error())
+		}
+	}
+
+	blogs, next, err := s.listPage(ctx, q, hashQuery(req), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	res := &blogpb.ListBlogsResponse{NextPageToken: next}
+	for _, blog := range blogs {
+		res.Blogs = append(res.Blogs, blog.toProto())
+	}
+	return res, nil
+}
+
+// listPage returns the blogs of q after pageToken, which must have been
+// issued for the same queryHash, and the token of the next page. q.Limit is
+// the page size.
+func (s *server) listPage(ctx context.Context, q *listQuery, queryHash, pageToken string) ([]*blogItem, string, error) {
+	if pageToken != "" {
+		after, err := decodePageToken(pageToken, queryHash)
+		if err != nil {
+			return nil, "", invalidField("page_token", err.Error())
+		}
+		q.After = after
+	}
+
+	// one more blog tells if there is a next page
+	pageSize := q.Limit
+	q.Limit++
+	blogs, err := s.blogs.List(ctx, q)
+	if err != nil {
+		log.Printf("Error while listing blogs: %v", err)
+		return nil, "", storageError("Cannot list the blogs of the storage")
+	}
+
+	if len(blogs) <= pageSize {
+		return blogs, "", nil
+	}
+	blogs = blogs[:pageSize]
+	return blogs, encodePageToken(q.cursor(blogs[pageSize-1]), queryHash), nil
+}
+
+// hashQuery identifies the filters and order of req, a page token only works
+// with the request it was issued for. The text of a message is not stable
+// across protobuf versions, the fields are hashed one by one.
+func hashQuery(req *blogpb.ListBlogsRequest) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%q %q %v %v %d %d",
+		req.GetAuthorId(), normalizeTag(req.GetTag()), hashTimestamp(req.GetCreatedAfter()), hashTimestamp(req.GetCreatedBefore()),
+		int32(req.GetOrder()), int32(req.GetStatus()))))
+	return hex.EncodeToString(sum[:8])
+}
+
+// hashTimestamp writes ts for hashQuery, "-" when not set
+func hashTimestamp(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d.%09d", ts.GetSeconds(), ts.GetNanos())
+}
+
+func encodePageToken(c blogCursor, queryHash string) string {
+	data, _ := json.Marshal(pageToken{
+		At:    c.At.UnixNano() / int64(time.Millisecond),
+		ID:    c.ID.Hex(),
+		Query: queryHash,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(s, queryHash string) (*blogCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("The page token is not valid")
+	}
+	token := pageToken{}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("The page token is not valid")
+	}
+	if token.Query != queryHash {
+		return nil, fmt.Errorf("The page token was issued for other filters or order")
+	}
+	id, err := primitive.ObjectIDFromHex(token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("The page token is not valid")
+	}
+	at := time.Unix(0, token.At*int64(time.Millisecond)).UTC()
+	return &blogCursor{At: at, ID: id}, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// normalizeTags lower cases tags and removes the empty and repeated ones
+func normalizeTags(tags []string) ([]string, error) {
+	var result []string
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || containsString(result, tag) {
+			continue
+		}
+		if len([]rune(tag)) > maxTagLength {
+			return nil, invalidField("blog.tags", fmt.Sprintf("Tags must be at most %v characters long", maxTagLength))
+		}
+		result = append(result, tag)
+	}
+	if len(result) > maxTags {
+		return nil, invalidField("blog.tags", fmt.Sprintf("A blog can have at most %v tags", maxTags))
+	}
+	return result, nil
+}
+
+// invalidField is the INVALID_ARGUMENT error of a bad request field
+func invalidField(field, description string) error {
+	return errorWithDetails(
+		status.New(codes.InvalidArgument, fmt.Sprintf("Invalid %v: %v", field, description)),
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				&errdetails.BadRequest_FieldViolation{
+					Field:       field,
+					Description: description,
+				},
+			},
+		},
+	)
+}
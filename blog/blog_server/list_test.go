@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+func TestHashQuery(t *testing.T) {
+	base := func() *blogpb.ListBlogsRequest {
+		return &blogpb.ListBlogsRequest{
+			AuthorId:     "author",
+			Tag:          "Go",
+			CreatedAfter: &timestamp.Timestamp{Seconds: 1577836800, Nanos: 5},
+			Order:        blogpb.ListBlogsRequest_OLDEST_FIRST,
+			Status:       blogpb.Blog_DRAFT,
+		}
+	}
+	want := hashQuery(base())
+
+	// the page size and token are not part of the query
+	same := base()
+	same.Tag = " go "
+	same.PageSize = 5
+	same.PageToken = "token"
+	if got := hashQuery(same); got != want {
+		t.Errorf("hash of the same query = %v, want %v", got, want)
+	}
+
+	changes := map[string]func(req *blogpb.ListBlogsRequest){
+		"author":         func(req *blogpb.ListBlogsRequest) { req.AuthorId = "other" },
+		"tag":            func(req *blogpb.ListBlogsRequest) { req.Tag = "rust" },
+		"created after":  func(req *blogpb.ListBlogsRequest) { req.CreatedAfter.Nanos = 6 },
+		"no time":        func(req *blogpb.ListBlogsRequest) { req.CreatedAfter = nil },
+		"zero time":      func(req *blogpb.ListBlogsRequest) { req.CreatedAfter = &timestamp.Timestamp{} },
+		"created before": func(req *blogpb.ListBlogsRequest) { req.CreatedBefore = &timestamp.Timestamp{Seconds: 1} },
+		"order":          func(req *blogpb.ListBlogsRequest) { req.Order = blogpb.ListBlogsRequest_NEWEST_FIRST },
+		"status":         func(req *blogpb.ListBlogsRequest) { req.Status = blogpb.Blog_PUBLISHED },
+	}
+	seen := map[string]string{want: "base"}
+	for name, change := range changes {
+		req := base()
+		change(req)
+		got := hashQuery(req)
+		if other, ok := seen[got]; ok {
+			t.Errorf("%v: same hash as %v", name, other)
+		}
+		seen[got] = name
+	}
+}
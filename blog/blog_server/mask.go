@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// blogFields copies a field of a blog, by its FieldMask path
+var blogFields = map[string]func(dst, src *blogpb.Blog){
+	"id":           func(dst, src *blogpb.Blog) { dst.Id = src.Id },
+	"author_id":    func(dst, src *blogpb.Blog) { dst.AuthorId = src.AuthorId },
+	"title":        func(dst, src *blogpb.Blog) { dst.Title = src.Title },
+	"content":      func(dst, src *blogpb.Blog) { dst.Content = src.Content },
+	"tags":         func(dst, src *blogpb.Blog) { dst.Tags = src.Tags },
+	"created_at":   func(dst, src *blogpb.Blog) { dst.CreatedAt = src.CreatedAt },
+	"updated_at":   func(dst, src *blogpb.Blog) { dst.UpdatedAt = src.UpdatedAt },
+	"revision":     func(dst, src *blogpb.Blog) { dst.Revision = src.Revision },
+	"etag":         func(dst, src *blogpb.Blog) { dst.Etag = src.Etag },
+	"status":       func(dst, src *blogpb.Blog) { dst.Status = src.Status },
+	"publish_at":   func(dst, src *blogpb.Blog) { dst.PublishAt = src.PublishAt },
+	"published_at": func(dst, src *blogpb.Blog) { dst.PublishedAt = src.PublishedAt },
+	"deleted_at":   func(dst, src *blogpb.Blog) { dst.DeletedAt = src.DeletedAt },
+	"external_id":  func(dst, src *blogpb.Blog) { dst.ExternalId = src.ExternalId },
+}
+
+// updatableFields are the paths UpdateBlog changes, the other fields are set
+// by the server
+var updatableFields = []string{"author_id", "title", "content", "tags"}
+
+// maskPaths checks the paths of the mask sent in field, "*" stands for all
+// the allowed ones. All of them are returned when the mask is empty.
+func maskPaths(mask *field_mask.FieldMask, field string, allowed []string) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return allowed, nil
+	}
+	var paths []string
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			return allowed, nil
+		}
+		if _, ok := blogFields[path]; !ok {
+			return nil, invalidField(field, fmt.Sprintf("Unknown blog field %q", path))
+		}
+		if !containsString(allowed, path) {
+			return nil, invalidField(field, fmt.Sprintf("The blog field %q is set by the server", path))
+		}
+		if !containsString(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// readFields returns the fields of the mask of a ReadBlogRequest
+func readFields(mask *field_mask.FieldMask) ([]string, error) {
+	all := make([]string, 0, len(blogFields))
+	for path := range blogFields {
+		all = append(all, path)
+	}
+	return maskPaths(mask, "read_mask", all)
+}
+
+// maskBlog returns a blog with only the fields of paths
+func maskBlog(blog *blogpb.Blog, paths []string) *blogpb.Blog {
+	if len(paths) == len(blogFields) {
+		return blog
+	}
+	masked := &blogpb.Blog{}
+	for _, path := range paths {
+		blogFields[path](masked, blog)
+	}
+	return masked
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// changes kept for the watchers resuming after one of them
+const eventLogSize = 1000
+
+// memoryStore keeps the blogs, their revisions and the idempotency keys in
+// memory, they are lost when the server stops
+type memoryStore struct {
+	keyTTL time.Duration
+	// feedID tells the resume tokens of the store from those of a previous
+	// server
+	feedID string
+
+	mu    sync.RWMutex
+	blogs map[primitive.ObjectID]*blogItem
+	// externalIDs has the ID of every imported blog, like the unique index of
+	// MongoDB
+	externalIDs map[string]primitive.ObjectID
+	index       *invertedIndex
+	revisions   map[primitive.ObjectID]map[int64]*revisionItem
+	keys        map[string]*idempotencyRecord
+	lastSweep   time.Time
+	// events has the last changes, numbered up to lastEvent, eventAdded is
+	// closed when one is added
+	events     []*blogEvent
+	lastEvent  int64
+	eventAdded chan struct{}
+}
+
+func newMemoryStore(keyTTL time.Duration) *memoryStore {
+	return &memoryStore{
+		keyTTL:      keyTTL,
+		feedID:      primitive.NewObjectID().Hex(),
+		blogs:       make(map[primitive.ObjectID]*blogItem),
+		externalIDs: make(map[string]primitive.ObjectID),
+		index:       newInvertedIndex(),
+		revisions:   make(map[primitive.ObjectID]map[int64]*revisionItem),
+		keys:        make(map[string]*idempotencyRecord),
+		eventAdded:  make(chan struct{}),
+	}
+}
+
+func (s *memoryStore) Insert(ctx context.Context, blog *blogItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insert(blog)
+}
+
+func (s *memoryStore) InsertMany(ctx context.Context, blogs []*blogItem) ([]error, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	errs := make([]error, len(blogs))
+	for i, blog := range blogs {
+		errs[i] = s.insert(blog)
+	}
+	return errs, nil
+}
+
+// insert adds blog, s.mu must be locked
+func (s *memoryStore) insert(blog *blogItem) error {
+	if blog.ExternalID != "" {
+		if _, ok := s.externalIDs[blog.ExternalID]; ok {
+			return errExternalIDExists
+		}
+		s.externalIDs[blog.ExternalID] = blog.ID
+	}
+	stored := *blog
+	s.blogs[blog.ID] = &stored
+	s.reindex(&stored)
+	s.addEvent(eventCreated, &stored)
+	return nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	blog, ok := s.blogs[id]
+	if !ok {
+		return nil, errBlogNotFound
+	}
+	copied := *blog
+	return &copied, nil
+}
+
+func (s *memoryStore) GetByExternalID(ctx context.Context, externalID string) (*blogItem, error) {
+	s.mu.RLock()
+	id, ok := s.externalIDs[externalID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errBlogNotFound
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *memoryStore) Delete(ctx context.Context, id primitive.ObjectID, revision int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blog, ok := s.blogs[id]
+	if !ok {
+		return errBlogNotFound
+	}
+	if blog.Revision != revision {
+		return errBlogChanged
+	}
+	delete(s.blogs, id)
+	delete(s.externalIDs, blog.ExternalID)
+	s.index.remove(id)
+	return nil
+}
+
+func (s *memoryStore) ApplyRevision(ctx context.Context, rev *revisionItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blog, ok := s.blogs[rev.BlogID]
+	if !ok {
+		return errBlogNotFound
+	}
+	if blog.Revision != rev.Number-1 {
+		return nil
+	}
+	event := eventUpdated
+	if !blog.deleted() && !rev.DeletedAt.IsZero() {
+		event = eventDeleted
+	}
+	blog.AuthorID = rev.AuthorID
+	blog.Title = rev.Title
+	blog.Content = rev.Content
+	blog.Tags = rev.Tags
+	blog.UpdatedAt = rev.CreatedAt
+	blog.Revision = rev.Number
+	blog.Status = rev.Status
+	blog.PublishAt = rev.PublishAt
+	blog.PublishedAt = rev.PublishedAt
+	blog.DeletedAt = rev.DeletedAt
+	s.reindex(blog)
+	s.addEvent(event, blog)
+	return nil
+}
+
+// reindex makes blog searchable only if it is published and not in the trash
+func (s *memoryStore) reindex(blog *blogItem) {
+	if effectiveStatus(blog.Status) == statusPublished && !blog.deleted() {
+		s.index.add(blog)
+	} else {
+		s.index.remove(blog.ID)
+	}
+}
+
+// addEvent tells the watchers about a change of blog, s.mu must be locked.
+// The blogs purged from the trash are not told, they were deleted when moved
+// to it.
+func (s *memoryStore) addEvent(eventType string, blog *blogItem) {
+	copied := *blog
+	s.lastEvent++
+	s.events = append(s.events, &blogEvent{
+		Type:  eventType,
+		Blog:  &copied,
+		Token: fmt.Sprintf("%v.%v", s.feedID, s.lastEvent),
+	})
+	if len(s.events) > eventLogSize {
+		s.events = s.events[len(s.events)-eventLogSize:]
+	}
+	close(s.eventAdded)
+	s.eventAdded = make(chan struct{})
+}
+
+func (s *memoryStore) Watch(ctx context.Context, token string, fn func(event *blogEvent) error) error {
+	s.mu.RLock()
+	after := s.lastEvent
+	s.mu.RUnlock()
+	if token != "" {
+		// the number of the last change received
+		parts := strings.Split(token, ".")
+		if len(parts) != 2 || parts[0] != s.feedID {
+			return errInvalidResumeToken
+		}
+		n, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || n < 0 || n > after {
+			return errInvalidResumeToken
+		}
+		after = n
+	}
+
+	for {
+		s.mu.RLock()
+		first := s.lastEvent - int64(len(s.events)) + 1
+		if after+1 < first {
+			s.mu.RUnlock()
+			return errResumeTokenExpired
+		}
+		// copied, fn may be slow to send them
+		events := append([]*blogEvent(nil), s.events[after+1-first:]...)
+		added := s.eventAdded
+		s.mu.RUnlock()
+
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return err
+			}
+			after++
+		}
+		if len(events) > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-added:
+		}
+	}
+}
+
+func (s *memoryStore) List(ctx context.Context, q *listQuery) ([]*blogItem, error) {
+	s.mu.RLock()
+	var blogs []*blogItem
+	for _, blog := range s.blogs {
+		if q.matches(blog) {
+			copied := *blog
+			blogs = append(blogs, &copied)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(blogs, func(i, j int) bool {
+		return q.less(q.cursor(blogs[i]), q.cursor(blogs[j]))
+	})
+	if len(blogs) > q.Limit {
+		blogs = blogs[:q.Limit]
+	}
+	return blogs, nil
+}
+
+func (s *memoryStore) Due(ctx context.Context, now time.Time, limit int) ([]*blogItem, error) {
+	s.mu.RLock()
+	var blogs []*blogItem
+	for _, blog := range s.blogs {
+		if blog.Status == statusScheduled && !blog.PublishAt.After(now) && !blog.deleted() {
+			copied := *blog
+			blogs = append(blogs, &copied)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(blogs, func(i, j int) bool {
+		return blogs[i].PublishAt.Before(blogs[j].PublishAt)
+	})
+	if len(blogs) > limit {
+		blogs = blogs[:limit]
+	}
+	return blogs, nil
+}
+
+func (s *memoryStore) Search(ctx context.Context, query string, limit int, fn func(blog *blogItem, score float64) error) error {
+	// copy the results, fn may be slow to send them
+	s.mu.RLock()
+	hits := s.index.search(query, limit)
+	blogs := make([]blogItem, len(hits))
+	for i, hit := range hits {
+		blogs[i] = *s.blogs[hit.id]
+	}
+	s.mu.RUnlock()
+
+	for i, hit := range hits {
+		if err := fn(&blogs[i], hit.score); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) AddRevision(ctx context.Context, rev *revisionItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addRevision(rev)
+}
+
+func (s *memoryStore) AddRevisions(ctx context.Context, revs []*revisionItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, rev := range revs {
+		if err := s.addRevision(rev); err != nil && err != errRevisionExists {
+			return err
+		}
+	}
+	return nil
+}
+
+// addRevision adds rev, s.mu must be locked
+func (s *memoryStore) addRevision(rev *revisionItem) error {
+	revisions, ok := s.revisions[rev.BlogID]
+	if !ok {
+		revisions = make(map[int64]*revisionItem)
+		s.revisions[rev.BlogID] = revisions
+	}
+	if _, ok := revisions[rev.Number]; ok {
+		return errRevisionExists
+	}
+	stored := *rev
+	if stored.ID.IsZero() {
+		stored.ID = primitive.NewObjectID()
+	}
+	revisions[rev.Number] = &stored
+	return nil
+}
+
+func (s *memoryStore) GetRevision(ctx context.Context, blogID primitive.ObjectID, number int64) (*revisionItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rev, ok := s.revisions[blogID][number]
+	if !ok {
+		return nil, errRevisionNotFound
+	}
+	copied := *rev
+	return &copied, nil
+}
+
+func (s *memoryStore) ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int) ([]*revisionItem, error) {
+	s.mu.RLock()
+	var revisions []*revisionItem
+	for number, rev := range s.revisions[blogID] {
+		if before == 0 || number < before {
+			copied := *rev
+			revisions = append(revisions, &copied)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number > revisions[j].Number
+	})
+	if len(revisions) > limit {
+		revisions = revisions[:limit]
+	}
+	return revisions, nil
+}
+
+func (s *memoryStore) DeleteRevisions(ctx context.Context, blogID primitive.ObjectID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.revisions, blogID)
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, record *idempotencyRecord) (*idempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// a new record is created now, by the clock of the server
+	s.sweepKeys(record.CreatedAt)
+	if existing, ok := s.keys[record.Key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	stored := *record
+	s.keys[record.Key] = &stored
+	return nil, nil
+}
+
+func (s *memoryStore) SetResponse(ctx context.Context, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.keys[key]; ok {
+		record.Response = response
+	}
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key string, createdAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.keys[key]; ok && record.CreatedAt.Equal(createdAt) {
+		delete(s.keys, key)
+	}
+	return nil
+}
+
+// sweepKeys forgets the expired idempotency keys, like the MongoDB TTL index
+func (s *memoryStore) sweepKeys(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+	for key, record := range s.keys {
+		if now.Sub(record.CreatedAt) > s.keyTTL {
+			delete(s.keys, key)
+		}
+	}
+}
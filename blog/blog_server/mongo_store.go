@@ -0,0 +1,564 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"github.com/mongodb/mongo-go-driver/mongo"
+	"github.com/mongodb/mongo-go-driver/mongo/options"
+	"github.com/mongodb/mongo-go-driver/x/bsonx"
+	"github.com/mongodb/mongo-go-driver/x/network/command"
+)
+
+// MongoDB error code of a duplicate _id
+const duplicateKeyCode = 11000
+
+// MongoDB error codes of a change stream that cannot resume, the oplog no
+// longer has the token
+const (
+	changeStreamFatalCode       = 280
+	changeStreamHistoryLostCode = 286
+)
+
+// mongoStore keeps the blogs, their revisions and the idempotency keys in MongoDB
+type mongoStore struct {
+	blogs     *mongo.Collection
+	revisions *mongo.Collection
+	keys      *mongo.Collection
+}
+
+// newMongoStore creates the indexes the store needs in db, the idempotency
+// keys are removed by MongoDB after keyTTL
+func newMongoStore(ctx context.Context, db *mongo.Database, keyTTL time.Duration) (*mongoStore, error) {
+	s := &mongoStore{
+		blogs:     db.Collection("blog"),
+		revisions: db.Collection("blog_revisions"),
+		keys:      db.Collection("idempotency_keys"),
+	}
+
+	// title matches count more than content matches
+	textIndex := mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "title", Value: bsonx.String("text")},
+			{Key: "content", Value: bsonx.String("text")},
+		},
+		Options: mongo.NewIndexOptionsBuilder().
+			Name("title_content_text").
+			Weights(bsonx.Doc{
+				{Key: "title", Value: bsonx.Int32(10)},
+				{Key: "content", Value: bsonx.Int32(1)},
+			}).
+			Build(),
+	}
+	if _, err := s.blogs.Indexes().CreateOne(ctx, textIndex); err != nil {
+		return nil, err
+	}
+
+	// the orders of ListBlogs, with and without filters, and of the trash
+	listIndexes := []mongo.IndexModel{
+		{
+			Keys: bsonx.Doc{
+				{Key: "created_at", Value: bsonx.Int32(-1)},
+				{Key: "_id", Value: bsonx.Int32(-1)},
+			},
+		},
+		{
+			Keys: bsonx.Doc{
+				{Key: "author_id", Value: bsonx.Int32(1)},
+				{Key: "created_at", Value: bsonx.Int32(-1)},
+				{Key: "_id", Value: bsonx.Int32(-1)},
+			},
+		},
+		{
+			Keys: bsonx.Doc{
+				{Key: "tags", Value: bsonx.Int32(1)},
+				{Key: "created_at", Value: bsonx.Int32(-1)},
+				{Key: "_id", Value: bsonx.Int32(-1)},
+			},
+		},
+		// the trash, only the deleted blogs have deleted_at
+		{
+			Keys: bsonx.Doc{
+				{Key: "deleted_at", Value: bsonx.Int32(-1)},
+				{Key: "_id", Value: bsonx.Int32(-1)},
+			},
+			Options: mongo.NewIndexOptionsBuilder().Sparse(true).Build(),
+		},
+	}
+	if _, err := s.blogs.Indexes().CreateMany(ctx, listIndexes); err != nil {
+		return nil, err
+	}
+
+	// an external ID is imported once, only the imported blogs have one
+	externalIDIndex := mongo.IndexModel{
+		Keys:    bsonx.Doc{{Key: "external_id", Value: bsonx.Int32(1)}},
+		Options: mongo.NewIndexOptionsBuilder().Name("external_id").Unique(true).Sparse(true).Build(),
+	}
+	if _, err := s.blogs.Indexes().CreateOne(ctx, externalIDIndex); err != nil {
+		return nil, err
+	}
+
+	// the scheduled blogs, by publication time
+	dueIndex := mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "status", Value: bsonx.Int32(1)},
+			{Key: "publish_at", Value: bsonx.Int32(1)},
+		},
+	}
+	if _, err := s.blogs.Indexes().CreateOne(ctx, dueIndex); err != nil {
+		return nil, err
+	}
+
+	// a revision number is taken once, the newest revisions are listed first
+	revisionIndex := mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "blog_id", Value: bsonx.Int32(1)},
+			{Key: "number", Value: bsonx.Int32(-1)},
+		},
+		Options: mongo.NewIndexOptionsBuilder().Name("blog_id_number").Unique(true).Build(),
+	}
+	if _, err := s.revisions.Indexes().CreateOne(ctx, revisionIndex); err != nil {
+		return nil, err
+	}
+
+	ttlIndex := mongo.IndexModel{
+		Keys:    bsonx.Doc{{Key: "created_at", Value: bsonx.Int32(1)}},
+		Options: mongo.NewIndexOptionsBuilder().Name("created_at_ttl").ExpireAfterSeconds(int32(keyTTL.Seconds())).Build(),
+	}
+	if _, err := s.keys.Indexes().CreateOne(ctx, ttlIndex); err != nil {
+		// the TTL changed since the index was created
+		if _, err := s.keys.Indexes().DropOne(ctx, "created_at_ttl"); err != nil {
+			return nil, err
+		}
+		if _, err := s.keys.Indexes().CreateOne(ctx, ttlIndex); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *mongoStore) Insert(ctx context.Context, blog *blogItem) error {
+	_, err := s.blogs.InsertOne(ctx, blog)
+	return err
+}
+
+func (s *mongoStore) InsertMany(ctx context.Context, blogs []*blogItem) ([]error, error) {
+	docs := make([]interface{}, len(blogs))
+	for i, blog := range blogs {
+		docs[i] = blog
+	}
+	// unordered, a blog failing does not stop the next ones
+	_, err := s.blogs.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	errs := make([]error, len(blogs))
+	if err == nil {
+		return errs, nil
+	}
+	bulkErr, ok := err.(mongo.BulkWriteException)
+	if !ok || bulkErr.WriteConcernError != nil {
+		return nil, err
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		errs[writeErr.Index] = writeErr
+		if writeErr.Code == duplicateKeyCode {
+			// the IDs are new, the external ID is taken
+			errs[writeErr.Index] = errExternalIDExists
+		}
+	}
+	return errs, nil
+}
+
+func (s *mongoStore) GetByExternalID(ctx context.Context, externalID string) (*blogItem, error) {
+	data := &blogItem{}
+	if err := s.blogs.FindOne(ctx, bson.M{"external_id": externalID}).Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errBlogNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *mongoStore) Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	data := &blogItem{}
+	if err := s.blogs.FindOne(ctx, bson.M{"_id": id}).Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errBlogNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *mongoStore) Delete(ctx context.Context, id primitive.ObjectID, revision int64) error {
+	res, err := s.blogs.DeleteOne(ctx, atRevision(id, revision))
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		// at another revision, or gone
+		if _, err := s.Get(ctx, id); err != nil {
+			return err
+		}
+		return errBlogChanged
+	}
+	return nil
+}
+
+// atRevision selects the blog with the ID if it is at the revision
+func atRevision(id primitive.ObjectID, revision int64) bson.M {
+	if revision == 0 {
+		// blogs created before the revisions were kept have none
+		return bson.M{"_id": id, "revision": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"_id": id, "revision": revision}
+}
+
+func (s *mongoStore) ApplyRevision(ctx context.Context, rev *revisionItem) error {
+	update := bson.M{"$set": bson.M{
+		"author_id":    rev.AuthorID,
+		"title":        rev.Title,
+		"content":      rev.Content,
+		"tags":         rev.Tags,
+		"updated_at":   rev.CreatedAt,
+		"revision":     rev.Number,
+		"status":       rev.Status,
+		"publish_at":   rev.PublishAt,
+		"published_at": rev.PublishedAt,
+	}}
+	if !rev.DeletedAt.IsZero() {
+		update["$set"].(bson.M)["deleted_at"] = rev.DeletedAt
+	} else {
+		update["$unset"] = bson.M{"deleted_at": ""}
+	}
+	res, err := s.blogs.UpdateOne(ctx, atRevision(rev.BlogID, rev.Number-1), update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		// at another revision, or gone
+		_, err := s.Get(ctx, rev.BlogID)
+		return err
+	}
+	return nil
+}
+
+func (s *mongoStore) List(ctx context.Context, q *listQuery) ([]*blogItem, error) {
+	filter := bson.M{}
+	if q.AuthorID != "" {
+		filter["author_id"] = q.AuthorID
+	}
+	if q.Tag != "" {
+		filter["tags"] = q.Tag
+	}
+	if q.Status != "" {
+		filter["status"] = statusFilter(q.Status)
+	}
+	created := bson.M{}
+	if !q.CreatedAfter.IsZero() {
+		created["$gte"] = q.CreatedAfter
+	}
+	if !q.CreatedBefore.IsZero() {
+		created["$lt"] = q.CreatedBefore
+	}
+	if len(created) > 0 {
+		filter["created_at"] = created
+	}
+	switch {
+	case !q.Deleted:
+		filter["deleted_at"] = nil
+	case !q.DeletedBefore.IsZero():
+		filter["deleted_at"] = bson.M{"$lt": q.DeletedBefore}
+	default:
+		filter["deleted_at"] = bson.M{"$ne": nil}
+	}
+
+	sortField := "created_at"
+	if q.ByDeletion {
+		sortField = "deleted_at"
+	}
+	direction, after := -1, "$lt"
+	if q.OldestFirst {
+		direction, after = 1, "$gt"
+	}
+	if q.After != nil {
+		filter["$or"] = bson.A{
+			bson.M{sortField: bson.M{after: q.After.At}},
+			bson.M{sortField: q.After.At, "_id": bson.M{after: q.After.ID}},
+		}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(int64(q.Limit))
+	cur, err := s.blogs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var blogs []*blogItem
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		blogs = append(blogs, data)
+	}
+	return blogs, cur.Err()
+}
+
+// statusFilter matches the blogs with status
+func statusFilter(status string) interface{} {
+	if status == statusPublished {
+		// blogs created before the statuses have none
+		return bson.M{"$in": bson.A{statusPublished, "", nil}}
+	}
+	return status
+}
+
+func (s *mongoStore) Due(ctx context.Context, now time.Time, limit int) ([]*blogItem, error) {
+	opts := options.Find().
+		SetSort(bson.M{"publish_at": 1}).
+		SetLimit(int64(limit))
+	filter := bson.M{"status": statusScheduled, "publish_at": bson.M{"$lte": now}, "deleted_at": nil}
+	cur, err := s.blogs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var blogs []*blogItem
+	for cur.Next(ctx) {
+		data := &blogItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		blogs = append(blogs, data)
+	}
+	return blogs, cur.Err()
+}
+
+// scoredBlogItem is a blogItem found by a text search
+type scoredBlogItem struct {
+	blogItem `bson:",inline"`
+	Score    float64 `bson:"score"`
+}
+
+func (s *mongoStore) Search(ctx context.Context, query string, limit int, fn func(blog *blogItem, score float64) error) error {
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.M{"score": score}).
+		SetLimit(int64(limit))
+	filter := bson.M{
+		"$text":      bson.M{"$search": query},
+		"status":     statusFilter(statusPublished),
+		"deleted_at": nil,
+	}
+	cur, err := s.blogs.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		data := &scoredBlogItem{}
+		if err := cur.Decode(data); err != nil {
+			return err
+		}
+		if err := fn(&data.blogItem, data.Score); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+func (s *mongoStore) AddRevision(ctx context.Context, rev *revisionItem) error {
+	if _, err := s.revisions.InsertOne(ctx, rev); err != nil {
+		if isDuplicateKey(err) {
+			return errRevisionExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *mongoStore) AddRevisions(ctx context.Context, revs []*revisionItem) error {
+	docs := make([]interface{}, len(revs))
+	for i, rev := range revs {
+		docs[i] = rev
+	}
+	_, err := s.revisions.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if bulkErr, ok := err.(mongo.BulkWriteException); ok && bulkErr.WriteConcernError == nil {
+		for _, writeErr := range bulkErr.WriteErrors {
+			if writeErr.Code != duplicateKeyCode {
+				return writeErr
+			}
+		}
+		return nil
+	}
+	return err
+}
+
+func (s *mongoStore) GetRevision(ctx context.Context, blogID primitive.ObjectID, number int64) (*revisionItem, error) {
+	data := &revisionItem{}
+	if err := s.revisions.FindOne(ctx, bson.M{"blog_id": blogID, "number": number}).Decode(data); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errRevisionNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *mongoStore) ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int) ([]*revisionItem, error) {
+	filter := bson.M{"blog_id": blogID}
+	if before > 0 {
+		filter["number"] = bson.M{"$lt": before}
+	}
+	opts := options.Find().
+		SetSort(bson.M{"number": -1}).
+		SetLimit(int64(limit))
+	cur, err := s.revisions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var revisions []*revisionItem
+	for cur.Next(ctx) {
+		data := &revisionItem{}
+		if err := cur.Decode(data); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, data)
+	}
+	return revisions, cur.Err()
+}
+
+func (s *mongoStore) DeleteRevisions(ctx context.Context, blogID primitive.ObjectID) error {
+	_, err := s.revisions.DeleteMany(ctx, bson.M{"blog_id": blogID})
+	return err
+}
+
+func (s *mongoStore) Claim(ctx context.Context, record *idempotencyRecord) (*idempotencyRecord, error) {
+	for {
+		_, err := s.keys.InsertOne(ctx, record)
+		if err == nil {
+			return nil, nil
+		}
+		if !isDuplicateKey(err) {
+			return nil, err
+		}
+
+		existing := &idempotencyRecord{}
+		if err := s.keys.FindOne(ctx, bson.M{"_id": record.Key}).Decode(existing); err != nil {
+			if err == mongo.ErrNoDocuments {
+				// removed in the meantime, claim it again
+				continue
+			}
+			return nil, err
+		}
+		return existing, nil
+	}
+}
+
+func (s *mongoStore) SetResponse(ctx context.Context, key string, response []byte) error {
+	_, err := s.keys.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{"response": response}})
+	return err
+}
+
+func (s *mongoStore) Release(ctx context.Context, key string, createdAt time.Time) error {
+	_, err := s.keys.DeleteOne(ctx, bson.M{"_id": key, "created_at": createdAt})
+	return err
+}
+
+// Watch follows a change stream of the blogs, the tokens are the resume
+// tokens of MongoDB. The blog of an update is read after it and may have
+// changed again since.
+func (s *mongoStore) Watch(ctx context.Context, token string, fn func(event *blogEvent) error) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if token != "" {
+		resumeAfter, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil || bson.Raw(resumeAfter).Validate() != nil {
+			return errInvalidResumeToken
+		}
+		opts.SetResumeAfter(resumeAfter)
+	}
+	// the blogs purged from the trash are not sent, they were deleted when
+	// moved to it
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace"}}}},
+	}
+	stream, err := s.blogs.Watch(ctx, pipeline, opts)
+	if err != nil {
+		return watchError(err)
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		raw, err := stream.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		var change struct {
+			OperationType     string    `bson:"operationType"`
+			FullDocument      *blogItem `bson:"fullDocument"`
+			UpdateDescription struct {
+				UpdatedFields bson.M `bson:"updatedFields"`
+			} `bson:"updateDescription"`
+		}
+		if err := bson.Unmarshal(raw, &change); err != nil {
+			return err
+		}
+		if change.FullDocument == nil {
+			// purged since
+			continue
+		}
+		event := &blogEvent{
+			Type:  eventUpdated,
+			Blog:  change.FullDocument,
+			Token: base64.RawURLEncoding.EncodeToString(raw.Lookup("_id").Document()),
+		}
+		if change.OperationType == "insert" {
+			event.Type = eventCreated
+		} else if _, ok := change.UpdateDescription.UpdatedFields["deleted_at"]; ok {
+			// only moving to the trash sets it
+			event.Type = eventDeleted
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return watchError(stream.Err())
+}
+
+// watchError is errResumeTokenExpired for the errors of a change stream that
+// cannot resume
+func watchError(err error) error {
+	if cmdErr, ok := err.(command.Error); ok {
+		if cmdErr.Code == changeStreamFatalCode || cmdErr.Code == changeStreamHistoryLostCode {
+			return errResumeTokenExpired
+		}
+	}
+	return err
+}
+
+func isDuplicateKey(err error) bool {
+	writeErrors, ok := err.(mongo.WriteErrors)
+	if !ok {
+		return false
+	}
+	for _, writeError := range writeErrors {
+		if writeError.Code == duplicateKeyCode {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *server) PublishBlog(ctx context.Context, req *blogpb.PublishBlogRequest) (*blogpb.PublishBlogResponse, error) {
+	fmt.Printf("PublishBlog function was invoked with %v\n", req)
+
+	data, err := s.changeStatus(ctx, req.GetBlogId(), req.GetEtag(), req.GetEditor(),
+		[]string{statusDraft, statusScheduled}, func(rev *revisionItem) {
+			rev.Status = statusPublished
+			rev.PublishAt = time.Time{}
+			rev.PublishedAt = s.now()
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &blogpb.PublishBlogResponse{
+		Blog: data.toProto(),
+	}, nil
+}
+
+func (s *server) UnpublishBlog(ctx context.Context, req *blogpb.UnpublishBlogRequest) (*blogpb.UnpublishBlogResponse, error) {
+	fmt.Printf("UnpublishBlog function was invoked with %v\n", req)
+
+	data, err := s.changeStatus(ctx, req.GetBlogId(), req.GetEtag(), req.GetEditor(),
+		[]string{statusPublished, statusScheduled, statusArchived}, func(rev *revisionItem) {
+			rev.Status = statusDraft
+			rev.PublishAt = time.Time{}
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &blogpb.UnpublishBlogResponse{
+		Blog: data.toProto(),
+	}, nil
+}
+
+func (s *server) ScheduleBlog(ctx context.Context, req *blogpb.ScheduleBlogRequest) (*blogpb.ScheduleBlogResponse, error) {
+	fmt.Printf("ScheduleBlog function was invoked with %v\n", req)
+
+	publishAt, err := ptypes.Timestamp(req.GetPublishAt())
+	if err != nil {
+		return nil, invalidField("publish_at", err.Error())
+	}
+	if !publishAt.After(s.clock.Now()) {
+		return nil, invalidField("publish_at", "The publication time must be in the future")
+	}
+
+	data, err := s.changeStatus(ctx, req.GetBlogId(), req.GetEtag(), req.GetEditor(),
+		[]string{statusDraft, statusScheduled}, func(rev *revisionItem) {
+			rev.Status = statusScheduled
+			rev.PublishAt = publishAt.UTC().Truncate(time.Millisecond)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &blogpb.ScheduleBlogResponse{
+		Blog: data.toProto(),
+	}, nil
+}
+
+func (s *server) ArchiveBlog(ctx context.Context, req *blogpb.ArchiveBlogRequest) (*blogpb.ArchiveBlogResponse, error) {
+	fmt.Printf("ArchiveBlog function was invoked with %v\n", req)
+
+	data, err := s.changeStatus(ctx, req.GetBlogId(), req.GetEtag(), req.GetEditor(),
+		[]string{statusPublished}, func(rev *revisionItem) {
+			rev.Status = statusArchived
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &blogpb.ArchiveBlogResponse{
+		Blog: data.toProto(),
+	}, nil
+}
+
+// changeStatus records the revision made by change from a blog in one of the
+// from statuses, or returns the error to send
+func (s *server) changeStatus(ctx context.Context, blogID, etag, editor string, from []string, change func(rev *revisionItem)) (*blogItem, error) {
+	oid, err := parseBlogID(blogID, "blog_id")
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := s.reviseBlog(ctx, oid, etag, func(current *blogItem) (*revisionItem, error) {
+		if !containsString(from, effectiveStatus(current.Status)) {
+			return nil, statusError(current, from)
+		}
+		rev := newRevision(current, editor)
+		if rev.Editor == "" {
+			rev.Editor = current.AuthorID
+		}
+		change(rev)
+		return rev, nil
+	})
+	if err != nil {
+		return nil, reviseError(blogID, err)
+	}
+	return data, nil
+}
+
+// statusError tells that the change needs the blog in one of the statuses
+func statusError(blog *blogItem, statuses []string) error {
+	var names []string
+	for _, s := range statuses {
+		names = append(names, statusProtos[s].String())
+	}
+	current := statusProtos[effectiveStatus(blog.Status)].String()
+	return errorWithDetails(
+		status.New(codes.FailedPrecondition, fmt.Sprintf("Blog %v is %v", blog.ID.Hex(), current)),
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				&errdetails.PreconditionFailure_Violation{
+					Type:        "STATUS",
+					Subject:     "blog/" + blog.ID.Hex(),
+					Description: fmt.Sprintf("The blog must be %v", strings.Join(names, " or ")),
+				},
+			},
+		},
+		&errdetails.ErrorInfo{
+			Reason:   "INVALID_BLOG_STATUS",
+			Domain:   errorDomain,
+			Metadata: map[string]string{"blog_id": blog.ID.Hex(), "status": current},
+		},
+	)
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// times a change is tried again when other changes take its revision number
+const reviseAttempts = 5
+
+// errTooManyChanges is returned by reviseBlog when every attempt lost the race
+var errTooManyChanges = errors.New("too many concurrent changes")
+
+// newRevision is the revision recording blog as it is now
+func newRevision(blog *blogItem, editor string) *revisionItem {
+	return &revisionItem{
+		BlogID:      blog.ID,
+		Number:      blog.Revision,
+		AuthorID:    blog.AuthorID,
+		Title:       blog.Title,
+		Content:     blog.Content,
+		Tags:        blog.Tags,
+		Editor:      editor,
+		CreatedAt:   blog.UpdatedAt,
+		Status:      blog.Status,
+		PublishAt:   blog.PublishAt,
+		PublishedAt: blog.PublishedAt,
+		DeletedAt:   blog.DeletedAt,
+	}
+}
+
+func (s *server) UpdateBlog(ctx context.Context, req *blogpb.UpdateBlogRequest) (*blogpb.UpdateBlogResponse, error) {
+	fmt.Printf("UpdateBlog function was invoked with %v\n", req)
+
+	blog := req.GetBlog()
+	oid, err := parseBlogID(blog.GetId(), "blog.id")
+	if err != nil {
+		return nil, err
+	}
+	paths, err := maskPaths(req.GetUpdateMask(), "update_mask", updatableFields)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(blog.GetTags())
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := s.reviseBlog(ctx, oid, blog.GetEtag(), func(current *blogItem) (*revisionItem, error) {
+		rev := newRevision(current, req.GetEditor())
+		for _, path := range paths {
+			switch path {
+			case "author_id":
+				rev.AuthorID = blog.GetAuthorId()
+			case "title":
+				rev.Title = blog.GetTitle()
+			case "content":
+				rev.Content = blog.GetContent()
+			case "tags":
+				rev.Tags = tags
+			}
+		}
+		if rev.Editor == "" {
+			rev.Editor = rev.AuthorID
+		}
+		return rev, nil
+	})
+	if err != nil {
+		return nil, reviseError(blog.GetId(), err)
+	}
+	return &blogpb.UpdateBlogResponse{
+		Blog: data.toProto(),
+	}, nil
+}
+
+func (s *server) ListBlogRevisions(ctx context.Context, req *blogpb.ListBlogRevisionsRequest) (*blogpb.ListBlogRevisionsResponse, error) {
+	fmt.Printf("ListBlogRevisions function was invoked with %v\n", req)
+
+	oid, err := parseBlogID(req.GetBlogId(), "blog_id")
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	// the token is the number of the last revision of the previous page
+	var before int64
+	if req.GetPageToken() != "" {
+		before, err = strconv.ParseInt(req.GetPageToken(), 10, 64)
+		if err != nil || before <= 1 {
+			return nil, invalidField("page_token", "The page token is not valid")
+		}
+	}
+
+	if _, err := s.getBlog(ctx, oid); err != nil {
+		return nil, reviseError(req.GetBlogId(), err)
+	}
+	revisions, err := s.revisions.ListRevisions(ctx, oid, before, pageSize+1)
+	if err != nil {
+		log.Printf("Error while listing the revisions of blog %v: %v", req.GetBlogId(), err)
+		return nil, storageError("Cannot list the revisions of the storage")
+	}
+
+	res := &blogpb.ListBlogRevisionsResponse{}
+	if len(revisions) > pageSize {
+		revisions = revisions[:pageSize]
+		res.NextPageToken = strconv.FormatInt(revisions[pageSize-1].Number, 10)
+	}
+	for _, rev := range revisions {
+		res.Revisions = append(res.Revisions, rev.toProto())
+	}
+	return res, nil
+}
+
+func (s *server) GetBlogRevision(ctx context.Context, req *blogpb.GetBlogRevisionRequest) (*blogpb.GetBlogRevisionResponse, error) {
+	fmt.Printf("GetBlogRevision function was invoked with %v\n", req)
+
+	rev, err := s.getRevision(ctx, req.GetBlogId(), req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	return &blogpb.GetBlogRevisionResponse{
+		Revision: rev.toProto(),
+	}, nil
+}
+
+func (s *server) RestoreBlogRevision(ctx context.Context, req *blogpb.RestoreBlogRevisionRequest) (*blogpb.RestoreBlogRevisionResponse, error) {
+	fmt.Printf("RestoreBlogRevision function was invoked with %v\n", req)
+
+	old, err := s.getRevision(ctx, req.GetBlogId(), req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	data, rev, err := s.reviseBlog(ctx, old.BlogID, req.GetEtag(), func(current *blogItem) (*revisionItem, error) {
+		// the content only, the blog keeps its status
+		rev := newRevision(current, req.GetEditor())
+		if rev.Editor == "" {
+			rev.Editor = current.AuthorID
+		}
+		rev.AuthorID = old.AuthorID
+		rev.Title = old.Title
+		rev.Content = old.Content
+		rev.Tags = old.Tags
+		rev.RestoredFrom = old.Number
+		return rev, nil
+	})
+	if err != nil {
+		return nil, reviseError(req.GetBlogId(), err)
+	}
+	return &blogpb.RestoreBlogRevisionResponse{
+		Blog:     data.toProto(),
+		Revision: rev.toProto(),
+	}, nil
+}
+
+func (s *server) DiffBlogRevisions(ctx context.Context, req *blogpb.DiffBlogRevisionsRequest) (*blogpb.DiffBlogRevisionsResponse, error) {
+	fmt.Printf("DiffBlogRevisions function was invoked with %v\n", req)
+
+	from, err := s.getRevision(ctx, req.GetBlogId(), req.GetFromNumber())
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getRevision(ctx, req.GetBlogId(), req.GetToNumber())
+	if err != nil {
+		return nil, err
+	}
+	fromName := fmt.Sprintf("revision %v", from.Number)
+	toName := fmt.Sprintf("revision %v", to.Number)
+	return &blogpb.DiffBlogRevisionsResponse{
+		TitleDiff:   unifiedDiff(fromName, toName, from.Title, to.Title),
+		ContentDiff: unifiedDiff(fromName, toName, from.Content, to.Content),
+	}, nil
+}
+
+// getRevision returns a revision of a blog, or the error to send
+func (s *server) getRevision(ctx context.Context, blogID string, number int64) (*revisionItem, error) {
+	oid, err := parseBlogID(blogID, "blog_id")
+	if err != nil {
+		return nil, err
+	}
+	rev, err := s.revisions.GetRevision(ctx, oid, number)
+	if err != nil {
+		if err == errRevisionNotFound {
+			return nil, errorWithDetails(
+				status.New(codes.NotFound, fmt.Sprintf("Cannot find revision %v of blog %v", number, blogID)),
+				&errdetails.ErrorInfo{
+					Reason:   "REVISION_NOT_FOUND",
+					Domain:   errorDomain,
+					Metadata: map[string]string{"blog_id": blogID, "number": strconv.FormatInt(number, 10)},
+				},
+			)
+		}
+		log.Printf("Error while reading revision %v of blog %v: %v", number, blogID, err)
+		return nil, storageError("Cannot read the revision from the storage")
+	}
+	return rev, nil
+}
+
+// reviseBlog records the revision made by change from the current blog and
+// applies it, only if the blog is at etag when it is set. change only fills
+// the content of the revision, its errors are returned as they are. The
+// blogs in the trash are not found.
+func (s *server) reviseBlog(ctx context.Context, id primitive.ObjectID, etag string, change func(current *blogItem) (*revisionItem, error)) (*blogItem, *revisionItem, error) {
+	return s.revise(ctx, id, etag, false, change)
+}
+
+// revise is reviseBlog for the blogs in the trash when deleted is true
+func (s *server) revise(ctx context.Context, id primitive.ObjectID, etag string, deleted bool, change func(current *blogItem) (*revisionItem, error)) (*blogItem, *revisionItem, error) {
+	for attempt := 0; attempt < reviseAttempts; attempt++ {
+		current, err := s.blogs.Get(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if current.deleted() != deleted {
+			return nil, nil, errBlogNotFound
+		}
+		if err := checkEtag(current, etag); err != nil {
+			return nil, nil, err
+		}
+		if current.Revision == 0 {
+			// created before the revisions were kept, it becomes the first one
+			// without changing, the etag follows
+			current.Revision = 1
+			if etag != "" {
+				etag = current.etag()
+			}
+			if err := s.recordRevision(ctx, newRevision(current, current.AuthorID)); err != nil && err != errRevisionExists {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		rev, err := change(current)
+		if err != nil {
+			return nil, nil, err
+		}
+		rev.BlogID = id
+		rev.Number = current.Revision + 1
+		rev.CreatedAt = s.now()
+		err = s.recordRevision(ctx, rev)
+		if err == errRevisionExists {
+			// another change took the number
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		current.AuthorID = rev.AuthorID
+		current.Title = rev.Title
+		current.Content = rev.Content
+		current.Tags = rev.Tags
+		current.UpdatedAt = rev.CreatedAt
+		current.Revision = rev.Number
+		current.Status = rev.Status
+		current.PublishAt = rev.PublishAt
+		current.PublishedAt = rev.PublishedAt
+		current.DeletedAt = rev.DeletedAt
+		return current, rev, nil
+	}
+	return nil, nil, errTooManyChanges
+}
+
+// recordRevision adds rev and applies it to its blog. The revision is the
+// commit point: when the number is taken, the revision holding it is applied
+// instead, its writer may have failed before doing it, and errRevisionExists
+// is returned.
+func (s *server) recordRevision(ctx context.Context, rev *revisionItem) error {
+	err := s.revisions.AddRevision(ctx, rev)
+	if err == errRevisionExists {
+		existing, err := s.revisions.GetRevision(ctx, rev.BlogID, rev.Number)
+		if err != nil {
+			return err
+		}
+		if err := s.blogs.ApplyRevision(ctx, existing); err != nil {
+			return err
+		}
+		return errRevisionExists
+	}
+	if err != nil {
+		return err
+	}
+	return s.blogs.ApplyRevision(ctx, rev)
+}
+
+// reviseError is the error to send for an error of reviseBlog
+func reviseError(blogID string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch err {
+	case errBlogNotFound:
+		return blogNotFound(blogID)
+	case errTooManyChanges:
+		return errorWithDetails(
+			status.New(codes.Aborted, fmt.Sprintf("Blog %v is changed by too many clients at once", blogID)),
+			&errdetails.ErrorInfo{
+				Reason:   "CONCURRENT_CHANGES",
+				Domain:   errorDomain,
+				Metadata: map[string]string{"blog_id": blogID},
+			},
+		)
+	}
+	log.Printf("Error while changing blog %v: %v", blogID, err)
+	return storageError("Cannot change the blog in the storage")
+}
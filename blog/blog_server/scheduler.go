@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// blogs published by a pass of the scheduler at most, the next pass goes on
+const scheduleBatch = 100
+
+// editor of the revisions made by the scheduler
+const schedulerEditor = "scheduler"
+
+// errNotDue is returned when a blog was unscheduled or rescheduled since it
+// was found due
+var errNotDue = errors.New("blog not due")
+
+// clock tells the time, a fake one lets the scheduler be tested without
+// waiting
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// scheduler publishes the scheduled blogs when they are due. It keeps no
+// state of its own: the blogs due while the server was stopped are published
+// by the first pass, and a blog is published once even when several servers
+// run a scheduler.
+type scheduler struct {
+	srv      *server
+	interval time.Duration
+}
+
+// every calls fn now and then every interval of c until ctx is done
+func every(ctx context.Context, c clock, interval time.Duration, fn func()) {
+	for {
+		fn()
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.After(interval):
+		}
+	}
+}
+
+// run makes a pass every interval until ctx is done
+func (sc *scheduler) run(ctx context.Context) {
+	every(ctx, sc.srv.clock, sc.interval, func() {
+		n, err := sc.publishDue(ctx)
+		if err != nil {
+			log.Printf("Error while publishing the scheduled blogs: %v", err)
+		}
+		if n > 0 {
+			log.Printf("Published %v scheduled blogs", n)
+		}
+	})
+}
+
+// publishDue publishes the blogs due now and returns how many
+func (sc *scheduler) publishDue(ctx context.Context) (int, error) {
+	now := sc.srv.clock.Now()
+	published := 0
+	for {
+		blogs, err := sc.srv.blogs.Due(ctx, now, scheduleBatch)
+		if err != nil {
+			return published, err
+		}
+		for _, blog := range blogs {
+			_, _, err := sc.srv.reviseBlog(ctx, blog.ID, "", func(current *blogItem) (*revisionItem, error) {
+				if current.Status != statusScheduled || current.PublishAt.After(now) {
+					return nil, errNotDue
+				}
+				rev := newRevision(current, schedulerEditor)
+				rev.Status = statusPublished
+				rev.PublishAt = time.Time{}
+				rev.PublishedAt = sc.srv.now()
+				return rev, nil
+			})
+			switch err {
+			case nil:
+				published++
+			case errNotDue, errBlogNotFound:
+			default:
+				return published, err
+			}
+		}
+		if len(blogs) < scheduleBatch {
+			return published, nil
+		}
+	}
+}
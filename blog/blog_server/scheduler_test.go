@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// fakeClock only moves when the test advances it, After fires on the ticks
+// sent by the test
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	ticks chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:   time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+		ticks: make(chan time.Time),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return c.ticks
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestServer returns a server on a memory store and a fake clock
+func newTestServer(store *memoryStore, c clock) *server {
+	srv := &server{clock: c}
+	srv.blogs, srv.revisions, srv.keys, srv.feed = store, store, store, store
+	return srv
+}
+
+// createScheduled creates a blog published by the scheduler at publishAt
+func createScheduled(t *testing.T, srv *server, title string, publishAt time.Time) primitive.ObjectID {
+	t.Helper()
+	ts, err := ptypes.TimestampProto(publishAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := srv.CreateBlog(context.Background(), &blogpb.CreateBlogRequest{
+		Blog: &blogpb.Blog{
+			AuthorId:  "author",
+			Title:     title,
+			Status:    blogpb.Blog_SCHEDULED,
+			PublishAt: ts,
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	id, err := primitive.ObjectIDFromHex(res.GetBlog().GetId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// wantStatus fails the test unless the blog has status
+func wantStatus(t *testing.T, srv *server, id primitive.ObjectID, status string) *blogItem {
+	t.Helper()
+	blog, err := srv.blogs.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Get %v: %v", id.Hex(), err)
+	}
+	if blog.Status != status {
+		t.Errorf("blog %q is %v, want %v", blog.Title, blog.Status, status)
+	}
+	return blog
+}
+
+func TestPublishDue(t *testing.T) {
+	c := newFakeClock()
+	srv := newTestServer(newMemoryStore(time.Hour), c)
+	sc := &scheduler{srv: srv, interval: time.Minute}
+	start := c.Now()
+	soon := createScheduled(t, srv, "soon", start.Add(time.Minute))
+	later := createScheduled(t, srv, "later", start.Add(time.Hour))
+
+	steps := []struct {
+		advance   time.Duration
+		published int
+		soon      string
+		later     string
+	}{
+		{0, 0, statusScheduled, statusScheduled},
+		{59 * time.Second, 0, statusScheduled, statusScheduled},
+		// due exactly at publish_at
+		{time.Second, 1, statusPublished, statusScheduled},
+		{30 * time.Minute, 0, statusPublished, statusScheduled},
+		{time.Hour, 1, statusPublished, statusPublished},
+		{time.Hour, 0, statusPublished, statusPublished},
+	}
+	for i, step := range steps {
+		c.advance(step.advance)
+		n, err := sc.publishDue(context.Background())
+		if err != nil {
+			t.Fatalf("step %v: publishDue: %v", i, err)
+		}
+		if n != step.published {
+			t.Errorf("step %v: published %v blogs, want %v", i, n, step.published)
+		}
+		wantStatus(t, srv, soon, step.soon)
+		wantStatus(t, srv, later, step.later)
+	}
+
+	blog := wantStatus(t, srv, soon, statusPublished)
+	if want := start.Add(time.Minute); !blog.PublishedAt.Equal(want) {
+		t.Errorf("published at %v, want %v", blog.PublishedAt, want)
+	}
+	if !blog.PublishAt.IsZero() {
+		t.Errorf("publish at %v once published, want none", blog.PublishAt)
+	}
+	rev, err := srv.revisions.GetRevision(context.Background(), soon, blog.Revision)
+	if err != nil {
+		t.Fatalf("GetRevision: %v", err)
+	}
+	if rev.Editor != schedulerEditor {
+		t.Errorf("revision edited by %q, want %q", rev.Editor, schedulerEditor)
+	}
+}
+
+func TestPublishOverdueOnRestart(t *testing.T) {
+	store := newMemoryStore(time.Hour)
+	c := newFakeClock()
+	before := newTestServer(store, c)
+	var overdue []primitive.ObjectID
+	for _, title := range []string{"first", "second", "third"} {
+		overdue = append(overdue, createScheduled(t, before, title, c.Now().Add(time.Minute)))
+	}
+	notDue := createScheduled(t, before, "not due", c.Now().Add(3*time.Hour))
+
+	// the server was stopped for two hours, a new one starts on the same store
+	c.advance(2 * time.Hour)
+	srv := newTestServer(store, c)
+	sc := &scheduler{srv: srv, interval: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sc.run(ctx)
+		close(done)
+	}()
+
+	// the first pass runs at once, before any tick
+	waitFor(t, func() bool {
+		for _, id := range overdue {
+			if blog, _ := srv.blogs.Get(ctx, id); blog.Status != statusPublished {
+				return false
+			}
+		}
+		return true
+	})
+	wantStatus(t, srv, notDue, statusScheduled)
+
+	// and the next ones on every tick
+	c.advance(time.Hour)
+	c.ticks <- c.Now()
+	waitFor(t, func() bool {
+		blog, _ := srv.blogs.Get(ctx, notDue)
+		return blog.Status == statusPublished
+	})
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the scheduler did not stop")
+	}
+}
+
+// waitFor polls cond until it is true, the scheduler runs in another goroutine
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// changedDueStore calls change after finding the due blogs, like another
+// client would between the lookup and the publication
+type changedDueStore struct {
+	blogStore
+	change func(blogs []*blogItem)
+}
+
+func (s *changedDueStore) Due(ctx context.Context, now time.Time, limit int) ([]*blogItem, error) {
+	blogs, err := s.blogStore.Due(ctx, now, limit)
+	if err == nil {
+		s.change(blogs)
+	}
+	return blogs, err
+}
+
+func TestPublishDueChangedBeforePublication(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(srv *server, id primitive.ObjectID) error
+		want   string
+	}{
+		{
+			name: "rescheduled",
+			change: func(srv *server, id primitive.ObjectID) error {
+				ts, _ := ptypes.TimestampProto(srv.clock.Now().Add(time.Hour))
+				_, err := srv.ScheduleBlog(context.Background(), &blogpb.ScheduleBlogRequest{BlogId: id.Hex(), PublishAt: ts})
+				return err
+			},
+			want: statusScheduled,
+		},
+		{
+			name: "unscheduled",
+			change: func(srv *server, id primitive.ObjectID) error {
+				_, err := srv.UnpublishBlog(context.Background(), &blogpb.UnpublishBlogRequest{BlogId: id.Hex()})
+				return err
+			},
+			want: statusDraft,
+		},
+		{
+			name: "published by hand",
+			change: func(srv *server, id primitive.ObjectID) error {
+				_, err := srv.PublishBlog(context.Background(), &blogpb.PublishBlogRequest{BlogId: id.Hex()})
+				return err
+			},
+			want: statusPublished,
+		},
+		{
+			name: "deleted",
+			change: func(srv *server, id primitive.ObjectID) error {
+				_, err := srv.DeleteBlog(context.Background(), &blogpb.DeleteBlogRequest{BlogId: id.Hex()})
+				return err
+			},
+			want: statusScheduled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClock()
+			store := newMemoryStore(time.Hour)
+			srv := newTestServer(store, c)
+			id := createScheduled(t, srv, tt.name, c.Now().Add(time.Minute))
+			c.advance(2 * time.Minute)
+
+			changed := &changedDueStore{blogStore: store}
+			changed.change = func(blogs []*blogItem) {
+				if len(blogs) != 1 {
+					t.Fatalf("%v blogs due, want 1", len(blogs))
+				}
+				if err := tt.change(srv, id); err != nil {
+					t.Fatalf("change: %v", err)
+				}
+			}
+			srv.blogs = changed
+			sc := &scheduler{srv: srv, interval: time.Minute}
+
+			n, err := sc.publishDue(context.Background())
+			if err != nil {
+				t.Fatalf("publishDue: %v", err)
+			}
+			if n != 0 {
+				t.Errorf("published %v blogs, want 0", n)
+			}
+			blog := wantStatus(t, srv, id, tt.want)
+			if blog.Revision != 2 {
+				t.Errorf("revision %v, want 2: the scheduler revised the blog", blog.Revision)
+			}
+		})
+	}
+}
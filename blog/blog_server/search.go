@@ -0,0 +1,258 @@
+package main
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// a title word counts as many content words
+const titleWeight = 10
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// words of a content snippet
+const snippetWords = 30
+
+// stopWords are too common to be searched, like MongoDB does
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"but": true, "by": true, "for": true, "if": true, "in": true, "into": true, "is": true,
+	"it": true, "no": true, "not": true, "of": true, "on": true, "or": true, "such": true,
+	"that": true, "the": true, "their": true, "then": true, "there": true, "these": true,
+	"they": true, "this": true, "to": true, "was": true, "will": true, "with": true,
+}
+
+// token is a word of a text, at text[start:end]
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits text in lower case words
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if word && start < 0 {
+			start = i
+		}
+		if !word && start >= 0 {
+			tokens = append(tokens, token{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return tokens
+}
+
+// terms returns the searchable words of text
+func terms(text string) []string {
+	var terms []string
+	for _, t := range tokenize(text) {
+		if !stopWords[t.term] {
+			terms = append(terms, t.term)
+		}
+	}
+	return terms
+}
+
+// invertedIndex finds the blogs containing a word, it is not safe for
+// concurrent use
+type invertedIndex struct {
+	// postings has the weighted frequency of every term in every blog
+	postings map[string]map[primitive.ObjectID]int
+	// docs has the terms of every blog, to remove them
+	docs map[primitive.ObjectID]map[string]int
+	// lengths has the weighted number of terms of every blog
+	lengths     map[primitive.ObjectID]int
+	totalLength int
+}
+
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{
+		postings: make(map[string]map[primitive.ObjectID]int),
+		docs:     make(map[primitive.ObjectID]map[string]int),
+		lengths:  make(map[primitive.ObjectID]int),
+	}
+}
+
+// frequencies counts the terms of blog, the title ones weigh more
+func frequencies(blog *blogItem) map[string]int {
+	freq := make(map[string]int)
+	for _, term := range terms(blog.Title) {
+		freq[term] += titleWeight
+	}
+	for _, term := range terms(blog.Content) {
+		freq[term]++
+	}
+	return freq
+}
+
+func (idx *invertedIndex) add(blog *blogItem) {
+	idx.remove(blog.ID)
+	freq := frequencies(blog)
+	length := 0
+	for term, n := range freq {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[primitive.ObjectID]int)
+		}
+		idx.postings[term][blog.ID] = n
+		length += n
+	}
+	idx.docs[blog.ID] = freq
+	idx.lengths[blog.ID] = length
+	idx.totalLength += length
+}
+
+func (idx *invertedIndex) remove(id primitive.ObjectID) {
+	length, ok := idx.lengths[id]
+	if !ok {
+		return
+	}
+	for term := range idx.docs[id] {
+		delete(idx.postings[term], id)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	delete(idx.docs, id)
+	delete(idx.lengths, id)
+	idx.totalLength -= length
+}
+
+type searchHit struct {
+	id    primitive.ObjectID
+	score float64
+}
+
+// search ranks the blogs containing any term of query with BM25
+func (idx *invertedIndex) search(query string, limit int) []searchHit {
+	if len(idx.lengths) == 0 {
+		return nil
+	}
+	n := float64(len(idx.lengths))
+	avgLength := float64(idx.totalLength) / n
+
+	scores := make(map[primitive.ObjectID]float64)
+	seen := make(map[string]bool)
+	for _, term := range terms(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		docs := idx.postings[term]
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, freq := range docs {
+			tf := float64(freq)
+			norm := 1 - bm25B + bm25B*float64(idx.lengths[id])/avgLength
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+
+	hits := make([]searchHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, searchHit{id, score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].id.Hex() < hits[j].id.Hex()
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// highlights returns the title and a part of the content of blog with the
+// words of query wrapped in <em></em>
+func highlights(blog *blogItem, query string) []*blogpb.Highlight {
+	matches := make(map[string]bool)
+	for _, term := range terms(query) {
+		matches[term] = true
+	}
+
+	var result []*blogpb.Highlight
+	if snippet, ok := highlight(blog.Title, matches, 0); ok {
+		result = append(result, &blogpb.Highlight{Field: "title", Snippet: snippet})
+	}
+	if snippet, ok := highlight(blog.Content, matches, snippetWords); ok {
+		result = append(result, &blogpb.Highlight{Field: "content", Snippet: snippet})
+	}
+	return result
+}
+
+// highlight wraps the matching words of text, escaped as HTML, keeping up to maxWords words
+// around the first one (all of them when 0). It returns false when no word
+// matches.
+func highlight(text string, matches map[string]bool, maxWords int) (string, bool) {
+	tokens := tokenize(text)
+	first := -1
+	for i, t := range tokens {
+		if matches[t.term] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	from, to := 0, len(tokens)
+	if maxWords > 0 && len(tokens) > maxWords {
+		// a little context before the first match
+		from = first - maxWords/4
+		if from < 0 {
+			from = 0
+		}
+		to = from + maxWords
+		if to > len(tokens) {
+			to = len(tokens)
+			from = to - maxWords
+		}
+	}
+
+	start, end := 0, len(text)
+	if from > 0 {
+		start = tokens[from].start
+	}
+	if to < len(tokens) {
+		end = tokens[to-1].end
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	pos := start
+	for _, t := range tokens[from:to] {
+		if !matches[t.term] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:t.start]))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(text[t.start:t.end]))
+		b.WriteString("</em>")
+		pos = t.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString("...")
+	}
+	return b.String(), true
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		query    string
+		maxWords int
+		want     string
+		wantOK   bool
+	}{
+		{
+			name:   "plain text",
+			text:   "Learning gRPC with Go",
+			query:  "grpc",
+			want:   "Learning <em>gRPC</em> with Go",
+			wantOK: true,
+		},
+		{
+			name:   "no match",
+			text:   "Learning gRPC with Go",
+			query:  "rust",
+			wantOK: false,
+		},
+		{
+			name:   "markup around the match",
+			text:   `<script>alert("hi")</script> & more`,
+			query:  "alert",
+			want:   `&lt;script&gt;<em>alert</em>(&#34;hi&#34;)&lt;/script&gt; &amp; more`,
+			wantOK: true,
+		},
+		{
+			name:   "markup matched",
+			text:   "<b>bold</b>",
+			query:  "b",
+			want:   "&lt;<em>b</em>&gt;bold&lt;/<em>b</em>&gt;",
+			wantOK: true,
+		},
+		{
+			name:     "markup in a cut snippet",
+			text:     "one two <b>three</b> four five six",
+			query:    "three",
+			maxWords: 4,
+			want:     "...b&gt;<em>three</em>&lt;/b&gt; four...",
+			wantOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := make(map[string]bool)
+			for _, term := range terms(tt.query) {
+				matches[term] = true
+			}
+			got, ok := highlight(tt.text, matches, tt.maxWords)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("highlight = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestHighlightsEscapeTheBlog(t *testing.T) {
+	blog := &blogItem{
+		Title:   `<img src=x onerror="alert(1)"> gRPC`,
+		Content: "Some <script>steal()</script> gRPC content",
+	}
+	for _, h := range highlights(blog, "grpc") {
+		snippet := strings.NewReplacer("<em>", "", "</em>", "").Replace(h.GetSnippet())
+		if strings.ContainsAny(snippet, `<>"`) {
+			t.Errorf("%v snippet %q is not escaped", h.GetField(), h.GetSnippet())
+		}
+	}
+}
@@ -2,134 +2,318 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
-
-	"github.com/mongodb/mongo-go-driver/bson"
+	"time"
 
 	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/FernandoDevBh/grpc-go-course/validation"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/mongodb/mongo-go-driver/bson/primitive"
 	"github.com/mongodb/mongo-go-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
-var collection *mongo.Collection
+var storage = flag.String("storage", "mongo", "where the blogs are kept: mongo or memory")
+var idempotencyTTL = flag.Duration("idempotency-ttl", 24*time.Hour, "how long CreateBlog idempotency keys are remembered")
+var scheduleInterval = flag.Duration("schedule-interval", 10*time.Second, "how often the scheduled blogs due are published")
+var trashRetention = flag.Duration("trash-retention", 30*24*time.Hour, "how long deleted blogs can be restored")
+var purgeInterval = flag.Duration("purge-interval", time.Hour, "how often the blogs past the trash retention are deleted for good")
+
+// domain of the ErrorInfo details attached to errors
+const errorDomain = "blog.grpc-go-course"
+
+// how long clients are told to wait before retrying a storage failure
+const storageRetryDelay = time.Second
+
+// blogs sent by SearchBlogs when the request has no limit
+const defaultSearchLimit = 20
 
 type server struct {
-	ID       primitive.ObjectID `bson:"_id, omitempty"`
-	AuthorID string             `bson:"author_id"`
-	Content  string             `bson:"content"`
-	Title    string             `bson:"title"`
+	blogs     blogStore
+	revisions revisionStore
+	keys      idempotencyStore
+	feed      blogFeed
+	clock     clock
 }
 
-type blogItem struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty"`
-	AuthorID string             `bson:"author_id"`
-	Content  string             `bson:"content"`
-	Title    string             `bson:"title"`
+// now is the time of a change, MongoDB keeps milliseconds
+func (s *server) now() time.Time {
+	return s.clock.Now().UTC().Truncate(time.Millisecond)
 }
 
-func (*server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
-	blog := req.GetBlog()
-	data := blogItem{
-		AuthorID: blog.GetAuthorId(),
-		Title:    blog.GetTitle(),
-		Content:  blog.GetContent(),
-	}
-	doc, err := collection.InsertOne(context.Background(), data)
+func (s *server) CreateBlog(ctx context.Context, req *blogpb.CreateBlogRequest) (*blogpb.CreateBlogResponse, error) {
+	key, err := idempotencyKey(ctx, req)
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Internal error: %v", err),
-		)
+		return nil, err
 	}
+	if key != "" {
+		return s.createBlogIdempotent(ctx, key, req.GetBlog())
+	}
+	return s.insertBlog(ctx, primitive.NewObjectID(), req.GetBlog())
+}
 
-	oid, ok := doc.InsertedID.(primitive.ObjectID)
-
-	if !ok {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Cannot convert to OID"),
-		)
+// insertBlog stores blog with the given id
+func (s *server) insertBlog(ctx context.Context, id primitive.ObjectID, blog *blogpb.Blog) (*blogpb.CreateBlogResponse, error) {
+	tags, err := normalizeTags(blog.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	data := blogItem{
+		ID:        id,
+		AuthorID:  blog.GetAuthorId(),
+		Title:     blog.GetTitle(),
+		Content:   blog.GetContent(),
+		Tags:      tags,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Revision:  1,
+	}
+	// published unless asked otherwise, like the blogs created before drafts
+	switch blog.GetStatus() {
+	case blogpb.Blog_STATUS_UNSPECIFIED, blogpb.Blog_PUBLISHED:
+		data.Status = statusPublished
+		data.PublishedAt = now
+	case blogpb.Blog_DRAFT:
+		data.Status = statusDraft
+	case blogpb.Blog_SCHEDULED:
+		publishAt, err := ptypes.Timestamp(blog.GetPublishAt())
+		if err != nil {
+			return nil, invalidField("blog.publish_at", "A SCHEDULED blog needs a publication time")
+		}
+		if !publishAt.After(s.clock.Now()) {
+			return nil, invalidField("blog.publish_at", "The publication time must be in the future")
+		}
+		data.Status = statusScheduled
+		data.PublishAt = publishAt.UTC().Truncate(time.Millisecond)
+	default:
+		return nil, invalidField("blog.status", "A new blog can be DRAFT, SCHEDULED or PUBLISHED")
+	}
+	// the revision first, a blog always has its current revision. The ID is
+	// only reused by an idempotent replay, which creates the same blog.
+	rev := newRevision(&data, data.AuthorID)
+	if err := s.revisions.AddRevision(ctx, rev); err != nil && err != errRevisionExists {
+		return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
+	}
+	if err := s.blogs.Insert(ctx, &data); err != nil {
+		return nil, status.Errorf(codes.Internal, "Internal error: %v", err)
 	}
 
 	return &blogpb.CreateBlogResponse{
-		Blog: &blogpb.Blog{
-			Id:       oid.Hex(),
-			AuthorId: blog.GetAuthorId(),
-			Title:    blog.GetTitle(),
-			Content:  blog.GetContent(),
-		},
+		Blog: data.toProto(),
 	}, nil
 }
 
-func (*server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
+func (s *server) ReadBlog(ctx context.Context, req *blogpb.ReadBlogRequest) (*blogpb.ReadBlogResponse, error) {
 	fmt.Println("Read blog request")
 
 	blogID := req.GetBlogId()
 
-	oid, err := primitive.ObjectIDFromHex(blogID)
+	oid, err := parseBlogID(blogID, "blog_id")
 	if err != nil {
-		return nil, status.Errorf(
-			codes.InvalidArgument,
-			fmt.Sprintf("Cannot parse ID"),
-		)
+		return nil, err
 	}
-	// create a empty struct
-	data := &blogItem{}
-	filter := bson.M{"_id": oid}
-
-	res := collection.FindOne(context.Background(), filter)
-	if err := res.Decode(data); err != nil {
-		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Cannot find blog with specified ID: %v", err),
-		)
+	paths, err := readFields(req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.getBlog(ctx, oid)
+	if err != nil {
+		if err == errBlogNotFound {
+			return nil, blogNotFound(blogID)
+		}
+		// anything else comes from the storage, the client may try again later
+		log.Printf("Error while reading blog %v: %v", blogID, err)
+		return nil, storageError("Cannot read blog from the storage")
 	}
 
 	return &blogpb.ReadBlogResponse{
-		Blog: &blogpb.Blog{
-			Id:       data.ID.Hex(),
-			AuthorId: data.AuthorID,
-			Title:    data.Title,
-			Content:  data.Content,
-		},
+		Blog: maskBlog(data.toProto(), paths),
 	}, nil
 }
 
-func main() {
-	// if we crash the go code, we get the file name and line number
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+// checkEtag fails with ABORTED when etag is set and blog is not at it
+func checkEtag(blog *blogItem, etag string) error {
+	if etag == "" || etag == blog.etag() {
+		return nil
+	}
+	return errorWithDetails(
+		status.New(codes.Aborted, fmt.Sprintf("Blog %v was changed since it was read, read it again", blog.ID.Hex())),
+		&errdetails.ErrorInfo{
+			Reason:   "ETAG_MISMATCH",
+			Domain:   errorDomain,
+			Metadata: map[string]string{"blog_id": blog.ID.Hex(), "etag": etag},
+		},
+	)
+}
+
+func (s *server) SearchBlogs(req *blogpb.SearchBlogsRequest, stream blogpb.BlogService_SearchBlogsServer) error {
+	fmt.Printf("SearchBlogs function was invoked with %v\n", req)
 
-	fmt.Println("Connecting to MongoDB")
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	var sendErr error
+	err := s.blogs.Search(stream.Context(), req.GetQuery(), limit, func(blog *blogItem, score float64) error {
+		sendErr = stream.Send(&blogpb.SearchBlogsResponse{
+			Blog:       blog.toProto(),
+			Score:      score,
+			Highlights: highlights(blog, req.GetQuery()),
+		})
+		return sendErr
+	})
+	if sendErr != nil {
+		// the client went away
+		return sendErr
+	}
+	if err != nil {
+		log.Printf("Error while searching blogs: %v", err)
+		return storageError("Cannot search the blogs in the storage")
+	}
+	return nil
+}
+
+// getBlog returns errBlogNotFound for the blogs in the trash
+func (s *server) getBlog(ctx context.Context, id primitive.ObjectID) (*blogItem, error) {
+	data, err := s.blogs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data.deleted() {
+		return nil, errBlogNotFound
+	}
+	return data, nil
+}
 
-	// connect to mongodb
-	client, err := mongo.NewClient("mongodb://localhost:27017")
+// parseBlogID reads the blog ID sent in field
+func parseBlogID(blogID, field string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
-		log.Fatal(err)
+		return oid, errorWithDetails(
+			status.New(codes.InvalidArgument, fmt.Sprintf("Cannot parse ID")),
+			&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					&errdetails.BadRequest_FieldViolation{
+						Field:       field,
+						Description: "The blog ID must be a 24 characters hex string",
+					},
+				},
+			},
+			&errdetails.ErrorInfo{
+				Reason:   "INVALID_BLOG_ID",
+				Domain:   errorDomain,
+				Metadata: map[string]string{"blog_id": blogID},
+			},
+		)
 	}
-	err = client.Connect(context.TODO())
+	return oid, nil
+}
+
+func blogNotFound(blogID string) error {
+	return errorWithDetails(
+		status.New(codes.NotFound, fmt.Sprintf("Cannot find blog with specified ID: %v", blogID)),
+		&errdetails.ErrorInfo{
+			Reason:   "BLOG_NOT_FOUND",
+			Domain:   errorDomain,
+			Metadata: map[string]string{"blog_id": blogID},
+		},
+	)
+}
+
+// storageError tells the client to try again later
+func storageError(message string) error {
+	return errorWithDetails(
+		status.New(codes.Unavailable, message),
+		&errdetails.ErrorInfo{
+			Reason: "STORAGE_UNAVAILABLE",
+			Domain: errorDomain,
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(storageRetryDelay),
+		},
+	)
+}
+
+// errorWithDetails attaches google.rpc error details to a status
+func errorWithDetails(st *status.Status, details ...proto.Message) error {
+	withDetails, err := st.WithDetails(details...)
 	if err != nil {
-		log.Fatal(err)
+		// the status without details is still meaningful
+		log.Printf("Cannot attach error details: %v", err)
+		return st.Err()
 	}
+	return withDetails.Err()
+}
+
+func main() {
+	// if we crash the go code, we get the file name and line number
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	flag.Parse()
+
+	srv := &server{clock: systemClock{}}
+	var client *mongo.Client
+	switch *storage {
+	case "mongo":
+		fmt.Println("Connecting to MongoDB")
 
-	collection = client.Database("mydb").Collection("blog")
+		// connect to mongodb
+		var err error
+		client, err = mongo.NewClient("mongodb://localhost:27017")
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = client.Connect(context.TODO())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		store, err := newMongoStore(context.TODO(), client.Database("mydb"), *idempotencyTTL)
+		if err != nil {
+			log.Fatalf("Cannot create the MongoDB indexes: %v", err)
+		}
+		srv.blogs, srv.revisions, srv.keys, srv.feed = store, store, store, store
+	case "memory":
+		fmt.Println("Keeping the blogs in memory")
+		store := newMemoryStore(*idempotencyTTL)
+		srv.blogs, srv.revisions, srv.keys, srv.feed = store, store, store, store
+	default:
+		log.Fatalf("Unknown storage %q, use mongo or memory", *storage)
+	}
 
 	fmt.Println("Blog Service Started")
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(validation.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(validation.StreamServerInterceptor("/blog.BlogService/ImportBlogs")),
+	}
 
 	s := grpc.NewServer(opts...)
 
-	blogpb.RegisterBlogServiceServer(s, &server{})
+	blogpb.RegisterBlogServiceServer(s, srv)
+
+	// Register reflection service on gRPC server.
+	reflection.Register(s)
+
+	// the scheduled blogs are published and the trash is purged in the background
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	sc := &scheduler{srv: srv, interval: *scheduleInterval}
+	go sc.run(backgroundCtx)
+	p := &purger{srv: srv, retention: *trashRetention, interval: *purgeInterval}
+	go p.run(backgroundCtx)
 
 	go func() {
 		fmt.Println("Starting Server...")
@@ -146,10 +330,13 @@ func main() {
 	// Block until a signal is received
 	<-ch
 	fmt.Println("\nStopping the server")
+	stopBackground()
 	s.Stop()
 	fmt.Println("Closing the listener")
 	lis.Close()
-	fmt.Println("Closing MongoDB Connection")
-	client.Disconnect(context.TODO())
+	if client != nil {
+		fmt.Println("Closing MongoDB Connection")
+		client.Disconnect(context.TODO())
+	}
 	fmt.Println("End of Program")
 }
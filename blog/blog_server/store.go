@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/mongodb/mongo-go-driver/bson/primitive"
+)
+
+// errBlogNotFound is returned by the stores when no blog has the ID
+var errBlogNotFound = errors.New("blog not found")
+
+// errBlogChanged is returned by Delete when the blog is not at the revision
+var errBlogChanged = errors.New("blog changed")
+
+// errRevisionNotFound is returned by the stores when a blog has no such revision
+var errRevisionNotFound = errors.New("revision not found")
+
+// errRevisionExists is returned by AddRevision when the number is taken
+var errRevisionExists = errors.New("revision already exists")
+
+// errExternalIDExists is returned by InsertMany for a blog whose external ID
+// is taken
+var errExternalIDExists = errors.New("external id already exists")
+
+// errResumeTokenExpired is returned by Watch when the events after the token
+// are no longer kept
+var errResumeTokenExpired = errors.New("resume token expired")
+
+// errInvalidResumeToken is returned by Watch for a token it did not send
+var errInvalidResumeToken = errors.New("invalid resume token")
+
+// statuses of a blog, as stored. Blogs created before the statuses have
+// none, they were published.
+const (
+	statusDraft     = "draft"
+	statusScheduled = "scheduled"
+	statusPublished = "published"
+	statusArchived  = "archived"
+)
+
+var statusProtos = map[string]blogpb.Blog_Status{
+	statusDraft:     blogpb.Blog_DRAFT,
+	statusScheduled: blogpb.Blog_SCHEDULED,
+	statusPublished: blogpb.Blog_PUBLISHED,
+	statusArchived:  blogpb.Blog_ARCHIVED,
+}
+
+// statusOf is the stored status of a Blog status, empty when unspecified
+func statusOf(status blogpb.Blog_Status) string {
+	for s, p := range statusProtos {
+		if p == status {
+			return s
+		}
+	}
+	return ""
+}
+
+// effectiveStatus is the status of a blog stored with status
+func effectiveStatus(status string) string {
+	if status == "" {
+		return statusPublished
+	}
+	return status
+}
+
+type blogItem struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	AuthorID  string             `bson:"author_id"`
+	Content   string             `bson:"content"`
+	Title     string             `bson:"title"`
+	Tags      []string           `bson:"tags"`
+	CreatedAt time.Time          `bson:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at"`
+	// Revision is the number of the last revision applied to the blog
+	Revision    int64     `bson:"revision"`
+	Status      string    `bson:"status"`
+	PublishAt   time.Time `bson:"publish_at"`
+	PublishedAt time.Time `bson:"published_at"`
+	// DeletedAt is set while the blog is in the trash
+	DeletedAt time.Time `bson:"deleted_at,omitempty"`
+	// ExternalID is unique among the imported blogs
+	ExternalID string `bson:"external_id,omitempty"`
+}
+
+func (b *blogItem) deleted() bool {
+	return !b.DeletedAt.IsZero()
+}
+
+func (b *blogItem) toProto() *blogpb.Blog {
+	return &blogpb.Blog{
+		Id:          b.ID.Hex(),
+		AuthorId:    b.AuthorID,
+		Title:       b.Title,
+		Content:     b.Content,
+		Tags:        b.Tags,
+		CreatedAt:   timestampProto(b.CreatedAt),
+		UpdatedAt:   timestampProto(b.UpdatedAt),
+		Revision:    b.Revision,
+		Etag:        b.etag(),
+		Status:      statusProtos[effectiveStatus(b.Status)],
+		PublishAt:   timestampProto(b.PublishAt),
+		PublishedAt: timestampProto(b.PublishedAt),
+		DeletedAt:   timestampProto(b.DeletedAt),
+		ExternalId:  b.ExternalID,
+	}
+}
+
+// etag changes with every revision of the blog
+func (b *blogItem) etag() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v/%v", b.ID.Hex(), b.Revision)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// revisionItem is a blog after a change
+type revisionItem struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID       primitive.ObjectID `bson:"blog_id"`
+	Number       int64              `bson:"number"`
+	AuthorID     string             `bson:"author_id"`
+	Title        string             `bson:"title"`
+	Content      string             `bson:"content"`
+	Tags         []string           `bson:"tags"`
+	Editor       string             `bson:"editor"`
+	CreatedAt    time.Time          `bson:"created_at"`
+	RestoredFrom int64              `bson:"restored_from"`
+	Status       string             `bson:"status"`
+	PublishAt    time.Time          `bson:"publish_at"`
+	PublishedAt  time.Time          `bson:"published_at"`
+	DeletedAt    time.Time          `bson:"deleted_at,omitempty"`
+}
+
+func (r *revisionItem) toProto() *blogpb.BlogRevision {
+	return &blogpb.BlogRevision{
+		BlogId:       r.BlogID.Hex(),
+		Number:       r.Number,
+		AuthorId:     r.AuthorID,
+		Title:        r.Title,
+		Content:      r.Content,
+		Tags:         r.Tags,
+		Editor:       r.Editor,
+		CreatedAt:    timestampProto(r.CreatedAt),
+		RestoredFrom: r.RestoredFrom,
+		Status:       statusProtos[effectiveStatus(r.Status)],
+		PublishAt:    timestampProto(r.PublishAt),
+		PublishedAt:  timestampProto(r.PublishedAt),
+		DeletedAt:    timestampProto(r.DeletedAt),
+	}
+}
+
+// timestampProto converts t, blogs created before the timestamps were kept have none
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		return nil
+	}
+	return ts
+}
+
+// blogCursor is the position of a blog in the order of a listQuery
+type blogCursor struct {
+	// At is the time the blogs are sorted by
+	At time.Time
+	ID primitive.ObjectID
+}
+
+// listQuery selects the blogs of a ListBlogs page
+type listQuery struct {
+	AuthorID      string
+	Tag           string
+	Status        string    // any when empty
+	CreatedAfter  time.Time // inclusive, ignored when zero
+	CreatedBefore time.Time // exclusive, ignored when zero
+	// Deleted selects the blogs in the trash instead of the others
+	Deleted       bool
+	DeletedBefore time.Time // exclusive, ignored when zero
+	// ByDeletion sorts by deletion instead of creation time
+	ByDeletion  bool
+	OldestFirst bool
+	// After is the last blog of the previous page, nil on the first page
+	After *blogCursor
+	Limit int
+}
+
+// matches tells if blog passes the filters of q and comes after q.After
+func (q *listQuery) matches(blog *blogItem) bool {
+	if q.AuthorID != "" && blog.AuthorID != q.AuthorID {
+		return false
+	}
+	if q.Tag != "" && !containsString(blog.Tags, q.Tag) {
+		return false
+	}
+	if q.Status != "" && effectiveStatus(blog.Status) != q.Status {
+		return false
+	}
+	if !q.CreatedAfter.IsZero() && blog.CreatedAt.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !blog.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	if q.Deleted != blog.deleted() {
+		return false
+	}
+	if !q.DeletedBefore.IsZero() && !blog.DeletedAt.Before(q.DeletedBefore) {
+		return false
+	}
+	return q.After == nil || q.less(*q.After, q.cursor(blog))
+}
+
+// cursor is the position of blog in the order of q
+func (q *listQuery) cursor(blog *blogItem) blogCursor {
+	if q.ByDeletion {
+		return blogCursor{blog.DeletedAt, blog.ID}
+	}
+	return blogCursor{blog.CreatedAt, blog.ID}
+}
+
+// less tells if a comes before b in the order of q
+func (q *listQuery) less(a, b blogCursor) bool {
+	if q.OldestFirst {
+		a, b = b, a
+	}
+	if !a.At.Equal(b.At) {
+		return a.At.After(b.At)
+	}
+	return a.ID.Hex() > b.ID.Hex()
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// blogStore keeps the blogs, in MongoDB or in memory
+type blogStore interface {
+	Insert(ctx context.Context, blog *blogItem) error
+	// InsertMany inserts the blogs and returns the error of each one, nil
+	// when it was inserted, or the error that failed all of them
+	InsertMany(ctx context.Context, blogs []*blogItem) ([]error, error)
+	// Get returns errBlogNotFound when there is no blog with the ID
+	Get(ctx context.Context, id primitive.ObjectID) (*blogItem, error)
+	// GetByExternalID returns errBlogNotFound when no blog was imported with
+	// the external ID, the blogs in the trash included
+	GetByExternalID(ctx context.Context, externalID string) (*blogItem, error)
+	// Delete deletes the blog, only if it is at the revision: errBlogChanged
+	// is returned otherwise
+	Delete(ctx context.Context, id primitive.ObjectID, revision int64) error
+	// ApplyRevision copies rev to its blog, unless the blog is not at the
+	// previous revision
+	ApplyRevision(ctx context.Context, rev *revisionItem) error
+	// List returns up to q.Limit blogs matching q, in its order
+	List(ctx context.Context, q *listQuery) ([]*blogItem, error)
+	// Due returns up to limit scheduled blogs to publish at or before now, the
+	// earliest first. The blogs in the trash are left out.
+	Due(ctx context.Context, now time.Time, limit int) ([]*blogItem, error)
+	// Search calls fn with up to limit published blogs matching any word of
+	// query, the most relevant first. The blogs in the trash are left out.
+	Search(ctx context.Context, query string, limit int, fn func(blog *blogItem, score float64) error) error
+}
+
+// revisionStore keeps the revisions of the blogs, they are only added
+type revisionStore interface {
+	// AddRevision returns errRevisionExists when the blog already has a
+	// revision with the number of rev
+	AddRevision(ctx context.Context, rev *revisionItem) error
+	// AddRevisions adds the revisions, the numbers already taken are left as
+	// they are
+	AddRevisions(ctx context.Context, revs []*revisionItem) error
+	// GetRevision returns errRevisionNotFound when the blog has no such revision
+	GetRevision(ctx context.Context, blogID primitive.ObjectID, number int64) (*revisionItem, error)
+	// ListRevisions returns up to limit revisions of the blog numbered below
+	// before (all of them when 0), the newest first
+	ListRevisions(ctx context.Context, blogID primitive.ObjectID, before int64, limit int) ([]*revisionItem, error)
+	DeleteRevisions(ctx context.Context, blogID primitive.ObjectID) error
+}
+
+// types of the changes of the blogs
+const (
+	eventCreated = "created"
+	eventUpdated = "updated"
+	eventDeleted = "deleted"
+)
+
+var eventProtos = map[string]blogpb.WatchBlogsResponse_Type{
+	eventCreated: blogpb.WatchBlogsResponse_CREATED,
+	eventUpdated: blogpb.WatchBlogsResponse_UPDATED,
+	eventDeleted: blogpb.WatchBlogsResponse_DELETED,
+}
+
+// blogEvent is a change of a blog
+type blogEvent struct {
+	Type string
+	Blog *blogItem
+	// Token resumes the changes after this one
+	Token string
+}
+
+// blogFeed sends the changes of the blogs, in the order they are made
+type blogFeed interface {
+	// Watch calls fn with the changes made after token, or from now on when
+	// it is empty, until ctx is done or fn fails. It returns
+	// errResumeTokenExpired or errInvalidResumeToken when it cannot resume
+	// after token.
+	Watch(ctx context.Context, token string, fn func(event *blogEvent) error) error
+}
+
+// idempotencyStore keeps the idempotency keys of CreateBlog
+type idempotencyStore interface {
+	// Claim stores record, unless its key is already taken: the record
+	// holding it is returned then
+	Claim(ctx context.Context, record *idempotencyRecord) (*idempotencyRecord, error)
+	SetResponse(ctx context.Context, key string, response []byte) error
+	// Release deletes the key, only if it was claimed at createdAt
+	Release(ctx context.Context, key string, createdAt time.Time) error
+}
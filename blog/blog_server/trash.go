@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+)
+
+// blogs deleted by a pass of the purger at most, the next pass goes on
+const purgeBatch = 100
+
+func (s *server) DeleteBlog(ctx context.Context, req *blogpb.DeleteBlogRequest) (*blogpb.DeleteBlogResponse, error) {
+	fmt.Printf("DeleteBlog function was invoked with %v\n", req)
+
+	blogID := req.GetBlogId()
+	oid, err := parseBlogID(blogID, "blog_id")
+	if err != nil {
+		return nil, err
+	}
+	_, _, err = s.reviseBlog(ctx, oid, req.GetEtag(), func(current *blogItem) (*revisionItem, error) {
+		rev := newRevision(current, req.GetEditor())
+		if rev.Editor == "" {
+			rev.Editor = current.AuthorID
+		}
+		rev.DeletedAt = s.now()
+		return rev, nil
+	})
+	if err != nil {
+		return nil, reviseError(blogID, err)
+	}
+	return &blogpb.DeleteBlogResponse{
+		BlogId: blogID,
+	}, nil
+}
+
+func (s *server) ListDeletedBlogs(ctx context.Context, req *blogpb.ListDeletedBlogsRequest) (*blogpb.ListDeletedBlogsResponse, error) {
+	fmt.Printf("ListDeletedBlogs function was invoked with %v\n", req)
+
+	q := &listQuery{
+		AuthorID:   req.GetAuthorId(),
+		Deleted:    true,
+		ByDeletion: true,
+		Limit:      int(req.GetPageSize()),
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultPageSize
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("deleted %q", req.GetAuthorId())))
+	blogs, next, err := s.listPage(ctx, q, hex.EncodeToString(sum[:8]), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	res := &blogpb.ListDeletedBlogsResponse{NextPageToken: next}
+	for _, blog := range blogs {
+		res.Blogs = append(res.Blogs, blog.toProto())
+	}
+	return res, nil
+}
+
+func (s *server) RestoreBlog(ctx context.Context, req *blogpb.RestoreBlogRequest) (*blogpb.RestoreBlogResponse, error) {
+	fmt.Printf("RestoreBlog function was invoked with %v\n", req)
+
+	blogID := req.GetBlogId()
+	oid, err := parseBlogID(blogID, "blog_id")
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := s.revise(ctx, oid, req.GetEtag(), true, func(current *blogItem) (*revisionItem, error) {
+		rev := newRevision(current, req.GetEditor())
+		if rev.Editor == "" {
+			rev.Editor = current.AuthorID
+		}
+		rev.DeletedAt = time.Time{}
+		return rev, nil
+	})
+	if err != nil {
+		return nil, reviseError(blogID, err)
+	}
+	return &blogpb.RestoreBlogResponse{
+		Blog: data.toProto(),
+	}, nil
+}
+
+// purger deletes for good the blogs in the trash for longer than the
+// retention, with their revisions
+type purger struct {
+	srv       *server
+	retention time.Duration
+	interval  time.Duration
+}
+
+// run makes a pass every interval until ctx is done
+func (p *purger) run(ctx context.Context) {
+	every(ctx, p.srv.clock, p.interval, func() {
+		n, err := p.purge(ctx)
+		if err != nil {
+			log.Printf("Error while purging the trash: %v", err)
+		}
+		if n > 0 {
+			log.Printf("Purged %v blogs from the trash", n)
+		}
+	})
+}
+
+// purge deletes the blogs past the retention and returns how many
+func (p *purger) purge(ctx context.Context) (int, error) {
+	q := &listQuery{
+		Deleted:       true,
+		DeletedBefore: p.srv.clock.Now().Add(-p.retention),
+		ByDeletion:    true,
+		OldestFirst:   true,
+		Limit:         purgeBatch,
+	}
+	purged := 0
+	for {
+		blogs, err := p.srv.blogs.List(ctx, q)
+		if err != nil {
+			return purged, err
+		}
+		for _, blog := range blogs {
+			// restored since it was listed unless it is still at the revision
+			err := p.srv.blogs.Delete(ctx, blog.ID, blog.Revision)
+			if err == errBlogChanged || err == errBlogNotFound {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+			if err := p.srv.revisions.DeleteRevisions(ctx, blog.ID); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+		if len(blogs) < purgeBatch {
+			return purged, nil
+		}
+		after := q.cursor(blogs[len(blogs)-1])
+		q.After = &after
+	}
+}
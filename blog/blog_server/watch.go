@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *server) WatchBlogs(req *blogpb.WatchBlogsRequest, stream blogpb.BlogService_WatchBlogsServer) error {
+	fmt.Printf("WatchBlogs function was invoked with %v\n", req)
+
+	var sendErr error
+	err := s.feed.Watch(stream.Context(), req.GetResumeToken(), func(event *blogEvent) error {
+		if req.GetAuthorId() != "" && event.Blog.AuthorID != req.GetAuthorId() {
+			return nil
+		}
+		sendErr = stream.Send(&blogpb.WatchBlogsResponse{
+			Type:        eventProtos[event.Type],
+			Blog:        event.Blog.toProto(),
+			ResumeToken: event.Token,
+		})
+		return sendErr
+	})
+	if sendErr != nil {
+		// the client went away
+		return sendErr
+	}
+	switch {
+	case stream.Context().Err() != nil:
+		return status.Error(codes.Canceled, "The watch was canceled")
+	case err == errInvalidResumeToken:
+		return invalidField("resume_token", "The resume token is not valid")
+	case err == errResumeTokenExpired:
+		return errorWithDetails(
+			status.New(codes.OutOfRange, "The changes after the resume token are no longer kept, read the blogs again and watch from now"),
+			&errdetails.ErrorInfo{
+				Reason:   "RESUME_TOKEN_EXPIRED",
+				Domain:   errorDomain,
+				Metadata: map[string]string{"resume_token": req.GetResumeToken()},
+			},
+		)
+	}
+	log.Printf("Error while watching blogs: %v", err)
+	return storageError("Cannot watch the blogs in the storage")
+}
@@ -6,7 +6,10 @@ package blogpb
 import (
 	context "context"
 	fmt "fmt"
+	_ "github.com/FernandoDevBh/grpc-go-course/validation/validationpb"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	field_mask "google.golang.org/genproto/protobuf/field_mask"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -22,11 +25,150 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Blog_Status int32
+
+const (
+	Blog_STATUS_UNSPECIFIED Blog_Status = 0
+	Blog_DRAFT              Blog_Status = 1
+	Blog_SCHEDULED          Blog_Status = 2
+	Blog_PUBLISHED          Blog_Status = 3
+	Blog_ARCHIVED           Blog_Status = 4
+)
+
+var Blog_Status_name = map[int32]string{
+	0: "STATUS_UNSPECIFIED",
+	1: "DRAFT",
+	2: "SCHEDULED",
+	3: "PUBLISHED",
+	4: "ARCHIVED",
+}
+
+var Blog_Status_value = map[string]int32{
+	"STATUS_UNSPECIFIED": 0,
+	"DRAFT":              1,
+	"SCHEDULED":          2,
+	"PUBLISHED":          3,
+	"ARCHIVED":           4,
+}
+
+func (x Blog_Status) String() string {
+	return proto.EnumName(Blog_Status_name, int32(x))
+}
+
+func (Blog_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{0, 0}
+}
+
+type ListBlogsRequest_Order int32
+
+const (
+	ListBlogsRequest_NEWEST_FIRST ListBlogsRequest_Order = 0
+	ListBlogsRequest_OLDEST_FIRST ListBlogsRequest_Order = 1
+)
+
+var ListBlogsRequest_Order_name = map[int32]string{
+	0: "NEWEST_FIRST",
+	1: "OLDEST_FIRST",
+}
+
+var ListBlogsRequest_Order_value = map[string]int32{
+	"NEWEST_FIRST": 0,
+	"OLDEST_FIRST": 1,
+}
+
+func (x ListBlogsRequest_Order) String() string {
+	return proto.EnumName(ListBlogsRequest_Order_name, int32(x))
+}
+
+func (ListBlogsRequest_Order) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{8, 0}
+}
+
+type WatchBlogsResponse_Type int32
+
+const (
+	WatchBlogsResponse_TYPE_UNSPECIFIED WatchBlogsResponse_Type = 0
+	WatchBlogsResponse_CREATED          WatchBlogsResponse_Type = 1
+	WatchBlogsResponse_UPDATED          WatchBlogsResponse_Type = 2
+	WatchBlogsResponse_DELETED          WatchBlogsResponse_Type = 3
+)
+
+var WatchBlogsResponse_Type_name = map[int32]string{
+	0: "TYPE_UNSPECIFIED",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+}
+
+var WatchBlogsResponse_Type_value = map[string]int32{
+	"TYPE_UNSPECIFIED": 0,
+	"CREATED":          1,
+	"UPDATED":          2,
+	"DELETED":          3,
+}
+
+func (x WatchBlogsResponse_Type) String() string {
+	return proto.EnumName(WatchBlogsResponse_Type_name, int32(x))
+}
+
+func (WatchBlogsResponse_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{36, 0}
+}
+
+type ImportBlogsResult_Action int32
+
+const (
+	ImportBlogsResult_ACTION_UNSPECIFIED ImportBlogsResult_Action = 0
+	ImportBlogsResult_CREATED            ImportBlogsResult_Action = 1
+	ImportBlogsResult_UPDATED            ImportBlogsResult_Action = 2
+	ImportBlogsResult_FAILED             ImportBlogsResult_Action = 3
+)
+
+var ImportBlogsResult_Action_name = map[int32]string{
+	0: "ACTION_UNSPECIFIED",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "FAILED",
+}
+
+var ImportBlogsResult_Action_value = map[string]int32{
+	"ACTION_UNSPECIFIED": 0,
+	"CREATED":            1,
+	"UPDATED":            2,
+	"FAILED":             3,
+}
+
+func (x ImportBlogsResult_Action) String() string {
+	return proto.EnumName(ImportBlogsResult_Action_name, int32(x))
+}
+
+func (ImportBlogsResult_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{38, 0}
+}
+
 type Blog struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AuthorId             string   `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
-	Title                string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Content              string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Id       string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId string   `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title    string   `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content  string   `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Tags     []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	// set by the server
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// number of the current revision, set by the server
+	Revision int64 `protobuf:"varint,8,opt,name=revision,proto3" json:"revision,omitempty"`
+	// changes with every write. When sent back with UpdateBlog, the update
+	// fails with ABORTED if the blog was changed since it was read.
+	Etag string `protobuf:"bytes,9,opt,name=etag,proto3" json:"etag,omitempty"`
+	// PUBLISHED for a new blog unless CreateBlog asks for DRAFT or SCHEDULED,
+	// then changed by PublishBlog, UnpublishBlog, ScheduleBlog and ArchiveBlog
+	Status      Blog_Status          `protobuf:"varint,10,opt,name=status,proto3,enum=blog.Blog_Status" json:"status,omitempty"`
+	PublishAt   *timestamp.Timestamp `protobuf:"bytes,11,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	PublishedAt *timestamp.Timestamp `protobuf:"bytes,12,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	// set when the blog is in the trash, it is only returned by ListDeletedBlogs
+	DeletedAt *timestamp.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// ID of the blog in the system it was imported from, set by ImportBlogs
+	ExternalId           string   `protobuf:"bytes,14,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -85,8 +227,84 @@ func (m *Blog) GetContent() string {
 	return ""
 }
 
+func (m *Blog) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Blog) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Blog) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *Blog) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Blog) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *Blog) GetStatus() Blog_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Blog_STATUS_UNSPECIFIED
+}
+
+func (m *Blog) GetPublishAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishAt
+	}
+	return nil
+}
+
+func (m *Blog) GetPublishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishedAt
+	}
+	return nil
+}
+
+func (m *Blog) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+func (m *Blog) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
 type CreateBlogRequest struct {
-	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// the blog is PUBLISHED unless blog.status is DRAFT, or SCHEDULED with
+	// blog.publish_at in the future
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// makes retries safe: a replay with the same key returns the original
+	// response, a different blog with the same key gets ALREADY_EXISTS.
+	// Can also be sent as the idempotency-key metadata.
+	IdempotencyKey       string   `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -124,6 +342,13 @@ func (m *CreateBlogRequest) GetBlog() *Blog {
 	return nil
 }
 
+func (m *CreateBlogRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -164,10 +389,12 @@ func (m *CreateBlogResponse) GetBlog() *Blog {
 }
 
 type ReadBlogRequest struct {
-	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// the fields of the blog to return, all of them when empty
+	ReadMask             *field_mask.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ReadBlogRequest) Reset()         { *m = ReadBlogRequest{} }
@@ -202,6 +429,13 @@ func (m *ReadBlogRequest) GetBlogId() string {
 	return ""
 }
 
+func (m *ReadBlogRequest) GetReadMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.ReadMask
+	}
+	return nil
+}
+
 type ReadBlogResponse struct {
 	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -241,138 +475,3068 @@ func (m *ReadBlogResponse) GetBlog() *Blog {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Blog)(nil), "blog.Blog")
-	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
-	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
-	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
-	proto.RegisterType((*ReadBlogResponse)(nil), "blog.ReadBlogResponse")
+type SearchBlogsRequest struct {
+	// words looked up in the title and the content, any of them matches
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// 20 when 0
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("blog/blogpb/blog.proto", fileDescriptor_a4b0406114889fe6) }
+func (m *SearchBlogsRequest) Reset()         { *m = SearchBlogsRequest{} }
+func (m *SearchBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsRequest) ProtoMessage()    {}
+func (*SearchBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{5}
+}
 
-var fileDescriptor_a4b0406114889fe6 = []byte{
-	// 264 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x51, 0x4d, 0x4b, 0xc3, 0x40,
-	0x10, 0x25, 0x31, 0xa6, 0xe9, 0x14, 0xfc, 0x18, 0xb4, 0x5d, 0x2a, 0x88, 0xe4, 0x24, 0x1e, 0x2a,
-	0xa4, 0x5e, 0x3c, 0x09, 0xf5, 0xd4, 0x6b, 0xbc, 0x79, 0x29, 0x49, 0x76, 0xa8, 0x0b, 0x21, 0x1b,
-	0x37, 0x5b, 0xff, 0x83, 0xff, 0x5a, 0x76, 0xd6, 0xda, 0xd2, 0x1c, 0xec, 0x65, 0x77, 0xdf, 0x9b,
-	0x37, 0xfb, 0xde, 0x30, 0x30, 0x2e, 0x6b, 0xbd, 0x7e, 0x74, 0x47, 0x5b, 0xf2, 0x35, 0x6b, 0x8d,
-	0xb6, 0x1a, 0x23, 0xf7, 0x4e, 0x2b, 0x88, 0x16, 0xb5, 0x5e, 0xe3, 0x19, 0x84, 0x4a, 0x8a, 0xe0,
-	0x2e, 0xb8, 0x1f, 0xe6, 0xa1, 0x92, 0x78, 0x03, 0xc3, 0x62, 0x63, 0x3f, 0xb4, 0x59, 0x29, 0x29,
-	0x42, 0xa6, 0x13, 0x4f, 0x2c, 0x25, 0x5e, 0xc1, 0xa9, 0x55, 0xb6, 0x26, 0x71, 0xc2, 0x05, 0x0f,
-	0x50, 0xc0, 0xa0, 0xd2, 0x8d, 0xa5, 0xc6, 0x8a, 0x88, 0xf9, 0x2d, 0x4c, 0xe7, 0x70, 0xf9, 0x6a,
-	0xa8, 0xb0, 0xe4, 0xac, 0x72, 0xfa, 0xdc, 0x50, 0x67, 0xf1, 0x16, 0x38, 0x01, 0x7b, 0x8e, 0x32,
-	0x98, 0x71, 0x34, 0x16, 0xf8, 0x64, 0x4f, 0x80, 0xfb, 0x4d, 0x5d, 0xab, 0x9b, 0x8e, 0xfe, 0xed,
-	0x7a, 0x80, 0xf3, 0x9c, 0x0a, 0xb9, 0x6f, 0x34, 0x81, 0x81, 0x2b, 0xad, 0xfe, 0xe6, 0x8b, 0x1d,
-	0x5c, 0xca, 0x34, 0x83, 0x8b, 0x9d, 0xf6, 0xb8, 0xff, 0xb3, 0xef, 0x00, 0x46, 0x0e, 0xbe, 0x91,
-	0xf9, 0x52, 0x15, 0xe1, 0x0b, 0xc0, 0x2e, 0x25, 0x4e, 0xbc, 0xbe, 0x37, 0xec, 0x54, 0xf4, 0x0b,
-	0xbf, 0x86, 0xcf, 0x90, 0x6c, 0x43, 0xe0, 0xb5, 0x57, 0x1d, 0x0c, 0x30, 0x1d, 0x1f, 0xd2, 0xbe,
-	0x75, 0x91, 0xbc, 0xc7, 0x7e, 0xad, 0x65, 0xcc, 0x2b, 0x9d, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff,
-	0xb3, 0xbd, 0xb4, 0xa6, 0xec, 0x01, 0x00, 0x00,
+func (m *SearchBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsRequest.Unmarshal(m, b)
+}
+func (m *SearchBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *SearchBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsRequest.Merge(m, src)
+}
+func (m *SearchBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsRequest.Size(m)
+}
+func (m *SearchBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_SearchBlogsRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SearchBlogsRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
 
-// BlogServiceClient is the client API for BlogService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type BlogServiceClient interface {
-	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
-	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
+func (m *SearchBlogsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
 }
 
-type blogServiceClient struct {
-	cc *grpc.ClientConn
+type Highlight struct {
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Snippet              string   `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewBlogServiceClient(cc *grpc.ClientConn) BlogServiceClient {
-	return &blogServiceClient{cc}
+func (m *Highlight) Reset()         { *m = Highlight{} }
+func (m *Highlight) String() string { return proto.CompactTextString(m) }
+func (*Highlight) ProtoMessage()    {}
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{6}
 }
 
-func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
-	out := new(CreateBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Highlight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Highlight.Unmarshal(m, b)
+}
+func (m *Highlight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Highlight.Marshal(b, m, deterministic)
+}
+func (m *Highlight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Highlight.Merge(m, src)
+}
+func (m *Highlight) XXX_Size() int {
+	return xxx_messageInfo_Highlight.Size(m)
+}
+func (m *Highlight) XXX_DiscardUnknown() {
+	xxx_messageInfo_Highlight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Highlight proto.InternalMessageInfo
+
+func (m *Highlight) GetField() string {
+	if m != nil {
+		return m.Field
 	}
-	return out, nil
+	return ""
 }
 
-func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
-	out := new(ReadBlogResponse)
-	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Highlight) GetSnippet() string {
+	if m != nil {
+		return m.Snippet
 	}
-	return out, nil
+	return ""
 }
 
-// BlogServiceServer is the server API for BlogService service.
-type BlogServiceServer interface {
-	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
-	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
+type SearchBlogsResponse struct {
+	Blog                 *Blog        `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	Score                float64      `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights           []*Highlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
-	s.RegisterService(&_BlogService_serviceDesc, srv)
+func (m *SearchBlogsResponse) Reset()         { *m = SearchBlogsResponse{} }
+func (m *SearchBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchBlogsResponse) ProtoMessage()    {}
+func (*SearchBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{7}
 }
 
-func _BlogService_CreateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBlogRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *SearchBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchBlogsResponse.Unmarshal(m, b)
+}
+func (m *SearchBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *SearchBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchBlogsResponse.Merge(m, src)
+}
+func (m *SearchBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_SearchBlogsResponse.Size(m)
+}
+func (m *SearchBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchBlogsResponse proto.InternalMessageInfo
+
+func (m *SearchBlogsResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
 	}
-	if interceptor == nil {
-		return srv.(BlogServiceServer).CreateBlog(ctx, in)
+	return nil
+}
+
+func (m *SearchBlogsResponse) GetScore() float64 {
+	if m != nil {
+		return m.Score
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/blog.BlogService/CreateBlog",
+	return 0
+}
+
+func (m *SearchBlogsResponse) GetHighlights() []*Highlight {
+	if m != nil {
+		return m.Highlights
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).CreateBlog(ctx, req.(*CreateBlogRequest))
+	return nil
+}
+
+type ListBlogsRequest struct {
+	// filters, all of them must match
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAfter  *timestamp.Timestamp   `protobuf:"bytes,2,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamp.Timestamp   `protobuf:"bytes,3,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Tag           string                 `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	Order         ListBlogsRequest_Order `protobuf:"varint,5,opt,name=order,proto3,enum=blog.ListBlogsRequest_Order" json:"order,omitempty"`
+	// 20 when 0
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, sent with the same filters and order
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// PUBLISHED when unspecified
+	Status               Blog_Status `protobuf:"varint,8,opt,name=status,proto3,enum=blog.Blog_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListBlogsRequest) Reset()         { *m = ListBlogsRequest{} }
+func (m *ListBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlogsRequest) ProtoMessage()    {}
+func (*ListBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{8}
+}
+
+func (m *ListBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogsRequest.Unmarshal(m, b)
+}
+func (m *ListBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogsRequest.Merge(m, src)
+}
+func (m *ListBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlogsRequest.Size(m)
+}
+func (m *ListBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogsRequest proto.InternalMessageInfo
+
+func (m *ListBlogsRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _BlogService_ReadBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadBlogRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ListBlogsRequest) GetCreatedAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAfter
 	}
-	if interceptor == nil {
-		return srv.(BlogServiceServer).ReadBlog(ctx, in)
+	return nil
+}
+
+func (m *ListBlogsRequest) GetCreatedBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedBefore
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/blog.BlogService/ReadBlog",
+	return nil
+}
+
+func (m *ListBlogsRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlogServiceServer).ReadBlog(ctx, req.(*ReadBlogRequest))
+	return ""
+}
+
+func (m *ListBlogsRequest) GetOrder() ListBlogsRequest_Order {
+	if m != nil {
+		return m.Order
 	}
-	return interceptor(ctx, in, info, handler)
+	return ListBlogsRequest_NEWEST_FIRST
 }
 
-var _BlogService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "blog.BlogService",
-	HandlerType: (*BlogServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateBlog",
-			Handler:    _BlogService_CreateBlog_Handler,
-		},
+func (m *ListBlogsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListBlogsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListBlogsRequest) GetStatus() Blog_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Blog_STATUS_UNSPECIFIED
+}
+
+type ListBlogsResponse struct {
+	Blogs []*Blog `protobuf:"bytes,1,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogsResponse) Reset()         { *m = ListBlogsResponse{} }
+func (m *ListBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlogsResponse) ProtoMessage()    {}
+func (*ListBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{9}
+}
+
+func (m *ListBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogsResponse.Unmarshal(m, b)
+}
+func (m *ListBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogsResponse.Merge(m, src)
+}
+func (m *ListBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlogsResponse.Size(m)
+}
+func (m *ListBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogsResponse proto.InternalMessageInfo
+
+func (m *ListBlogsResponse) GetBlogs() []*Blog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+func (m *ListBlogsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type UpdateBlogRequest struct {
+	// replaces the fields of update_mask in the blog with this id, only if it
+	// is still at the etag of blog when it has one
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// recorded in the revision, the author of the blog when empty
+	Editor string `protobuf:"bytes,2,opt,name=editor,proto3" json:"editor,omitempty"`
+	// author_id, title, content or tags. The rules of the blog fields are only
+	// checked for these paths. All of them when empty or "*".
+	UpdateMask           *field_mask.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *UpdateBlogRequest) Reset()         { *m = UpdateBlogRequest{} }
+func (m *UpdateBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateBlogRequest) ProtoMessage()    {}
+func (*UpdateBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{10}
+}
+
+func (m *UpdateBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateBlogRequest.Unmarshal(m, b)
+}
+func (m *UpdateBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateBlogRequest.Merge(m, src)
+}
+func (m *UpdateBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateBlogRequest.Size(m)
+}
+func (m *UpdateBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateBlogRequest proto.InternalMessageInfo
+
+func (m *UpdateBlogRequest) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *UpdateBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+func (m *UpdateBlogRequest) GetUpdateMask() *field_mask.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateBlogResponse) Reset()         { *m = UpdateBlogResponse{} }
+func (m *UpdateBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateBlogResponse) ProtoMessage()    {}
+func (*UpdateBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{11}
+}
+
+func (m *UpdateBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateBlogResponse.Unmarshal(m, b)
+}
+func (m *UpdateBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateBlogResponse.Merge(m, src)
+}
+func (m *UpdateBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateBlogResponse.Size(m)
+}
+func (m *UpdateBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateBlogResponse proto.InternalMessageInfo
+
+func (m *UpdateBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type DeleteBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// fails with ABORTED unless the blog is at this etag, when set
+	Etag string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// recorded in the revision, the author of the blog when empty
+	Editor               string   `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBlogRequest) Reset()         { *m = DeleteBlogRequest{} }
+func (m *DeleteBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteBlogRequest) ProtoMessage()    {}
+func (*DeleteBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{12}
+}
+
+func (m *DeleteBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBlogRequest.Unmarshal(m, b)
+}
+func (m *DeleteBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBlogRequest.Merge(m, src)
+}
+func (m *DeleteBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteBlogRequest.Size(m)
+}
+func (m *DeleteBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBlogRequest proto.InternalMessageInfo
+
+func (m *DeleteBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *DeleteBlogRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *DeleteBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+type DeleteBlogResponse struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteBlogResponse) Reset()         { *m = DeleteBlogResponse{} }
+func (m *DeleteBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteBlogResponse) ProtoMessage()    {}
+func (*DeleteBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{13}
+}
+
+func (m *DeleteBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteBlogResponse.Unmarshal(m, b)
+}
+func (m *DeleteBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteBlogResponse.Merge(m, src)
+}
+func (m *DeleteBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteBlogResponse.Size(m)
+}
+func (m *DeleteBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteBlogResponse proto.InternalMessageInfo
+
+func (m *DeleteBlogResponse) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+type ListDeletedBlogsRequest struct {
+	AuthorId string `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// 20 when 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, sent with the same author
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeletedBlogsRequest) Reset()         { *m = ListDeletedBlogsRequest{} }
+func (m *ListDeletedBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeletedBlogsRequest) ProtoMessage()    {}
+func (*ListDeletedBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{14}
+}
+
+func (m *ListDeletedBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeletedBlogsRequest.Unmarshal(m, b)
+}
+func (m *ListDeletedBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeletedBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeletedBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeletedBlogsRequest.Merge(m, src)
+}
+func (m *ListDeletedBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeletedBlogsRequest.Size(m)
+}
+func (m *ListDeletedBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeletedBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeletedBlogsRequest proto.InternalMessageInfo
+
+func (m *ListDeletedBlogsRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *ListDeletedBlogsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDeletedBlogsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListDeletedBlogsResponse struct {
+	Blogs []*Blog `protobuf:"bytes,1,rep,name=blogs,proto3" json:"blogs,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeletedBlogsResponse) Reset()         { *m = ListDeletedBlogsResponse{} }
+func (m *ListDeletedBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeletedBlogsResponse) ProtoMessage()    {}
+func (*ListDeletedBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{15}
+}
+
+func (m *ListDeletedBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeletedBlogsResponse.Unmarshal(m, b)
+}
+func (m *ListDeletedBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeletedBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeletedBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeletedBlogsResponse.Merge(m, src)
+}
+func (m *ListDeletedBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeletedBlogsResponse.Size(m)
+}
+func (m *ListDeletedBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeletedBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeletedBlogsResponse proto.InternalMessageInfo
+
+func (m *ListDeletedBlogsResponse) GetBlogs() []*Blog {
+	if m != nil {
+		return m.Blogs
+	}
+	return nil
+}
+
+func (m *ListDeletedBlogsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type RestoreBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// fails with ABORTED unless the blog is at this etag, when set
+	Etag string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// recorded in the revision, the author of the blog when empty
+	Editor               string   `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBlogRequest) Reset()         { *m = RestoreBlogRequest{} }
+func (m *RestoreBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogRequest) ProtoMessage()    {}
+func (*RestoreBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{16}
+}
+
+func (m *RestoreBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogRequest.Unmarshal(m, b)
+}
+func (m *RestoreBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogRequest.Merge(m, src)
+}
+func (m *RestoreBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogRequest.Size(m)
+}
+func (m *RestoreBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBlogRequest proto.InternalMessageInfo
+
+func (m *RestoreBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *RestoreBlogRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *RestoreBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+type RestoreBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBlogResponse) Reset()         { *m = RestoreBlogResponse{} }
+func (m *RestoreBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogResponse) ProtoMessage()    {}
+func (*RestoreBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{17}
+}
+
+func (m *RestoreBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogResponse.Unmarshal(m, b)
+}
+func (m *RestoreBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogResponse.Merge(m, src)
+}
+func (m *RestoreBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogResponse.Size(m)
+}
+func (m *RestoreBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBlogResponse proto.InternalMessageInfo
+
+func (m *RestoreBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+// BlogRevision is a blog after a change, revisions are never modified
+type BlogRevision struct {
+	BlogId    string               `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Number    int64                `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	AuthorId  string               `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Title     string               `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Content   string               `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Tags      []string             `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Editor    string               `protobuf:"bytes,7,opt,name=editor,proto3" json:"editor,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// the revision copied by RestoreBlogRevision, 0 otherwise
+	RestoredFrom         int64                `protobuf:"varint,9,opt,name=restored_from,json=restoredFrom,proto3" json:"restored_from,omitempty"`
+	Status               Blog_Status          `protobuf:"varint,10,opt,name=status,proto3,enum=blog.Blog_Status" json:"status,omitempty"`
+	PublishAt            *timestamp.Timestamp `protobuf:"bytes,11,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	PublishedAt          *timestamp.Timestamp `protobuf:"bytes,12,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	DeletedAt            *timestamp.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BlogRevision) Reset()         { *m = BlogRevision{} }
+func (m *BlogRevision) String() string { return proto.CompactTextString(m) }
+func (*BlogRevision) ProtoMessage()    {}
+func (*BlogRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{18}
+}
+
+func (m *BlogRevision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlogRevision.Unmarshal(m, b)
+}
+func (m *BlogRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlogRevision.Marshal(b, m, deterministic)
+}
+func (m *BlogRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlogRevision.Merge(m, src)
+}
+func (m *BlogRevision) XXX_Size() int {
+	return xxx_messageInfo_BlogRevision.Size(m)
+}
+func (m *BlogRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlogRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlogRevision proto.InternalMessageInfo
+
+func (m *BlogRevision) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlogRevision) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+func (m *BlogRevision) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetRestoredFrom() int64 {
+	if m != nil {
+		return m.RestoredFrom
+	}
+	return 0
+}
+
+func (m *BlogRevision) GetStatus() Blog_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Blog_STATUS_UNSPECIFIED
+}
+
+func (m *BlogRevision) GetPublishAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishAt
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetPublishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishedAt
+	}
+	return nil
+}
+
+func (m *BlogRevision) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+// from DRAFT or SCHEDULED
+type PublishBlogRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Editor               string   `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBlogRequest) Reset()         { *m = PublishBlogRequest{} }
+func (m *PublishBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishBlogRequest) ProtoMessage()    {}
+func (*PublishBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{19}
+}
+
+func (m *PublishBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBlogRequest.Unmarshal(m, b)
+}
+func (m *PublishBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBlogRequest.Merge(m, src)
+}
+func (m *PublishBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishBlogRequest.Size(m)
+}
+func (m *PublishBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBlogRequest proto.InternalMessageInfo
+
+func (m *PublishBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *PublishBlogRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *PublishBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+type PublishBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishBlogResponse) Reset()         { *m = PublishBlogResponse{} }
+func (m *PublishBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishBlogResponse) ProtoMessage()    {}
+func (*PublishBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{20}
+}
+
+func (m *PublishBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishBlogResponse.Unmarshal(m, b)
+}
+func (m *PublishBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishBlogResponse.Merge(m, src)
+}
+func (m *PublishBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishBlogResponse.Size(m)
+}
+func (m *PublishBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishBlogResponse proto.InternalMessageInfo
+
+func (m *PublishBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+// from PUBLISHED, SCHEDULED or ARCHIVED
+type UnpublishBlogRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Editor               string   `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpublishBlogRequest) Reset()         { *m = UnpublishBlogRequest{} }
+func (m *UnpublishBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*UnpublishBlogRequest) ProtoMessage()    {}
+func (*UnpublishBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{21}
+}
+
+func (m *UnpublishBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpublishBlogRequest.Unmarshal(m, b)
+}
+func (m *UnpublishBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpublishBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *UnpublishBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpublishBlogRequest.Merge(m, src)
+}
+func (m *UnpublishBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_UnpublishBlogRequest.Size(m)
+}
+func (m *UnpublishBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpublishBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpublishBlogRequest proto.InternalMessageInfo
+
+func (m *UnpublishBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *UnpublishBlogRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *UnpublishBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+type UnpublishBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnpublishBlogResponse) Reset()         { *m = UnpublishBlogResponse{} }
+func (m *UnpublishBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*UnpublishBlogResponse) ProtoMessage()    {}
+func (*UnpublishBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{22}
+}
+
+func (m *UnpublishBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnpublishBlogResponse.Unmarshal(m, b)
+}
+func (m *UnpublishBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnpublishBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *UnpublishBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnpublishBlogResponse.Merge(m, src)
+}
+func (m *UnpublishBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_UnpublishBlogResponse.Size(m)
+}
+func (m *UnpublishBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnpublishBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnpublishBlogResponse proto.InternalMessageInfo
+
+func (m *UnpublishBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+// from DRAFT or SCHEDULED
+type ScheduleBlogRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// must be in the future
+	PublishAt            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	Etag                 string               `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	Editor               string               `protobuf:"bytes,4,opt,name=editor,proto3" json:"editor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ScheduleBlogRequest) Reset()         { *m = ScheduleBlogRequest{} }
+func (m *ScheduleBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*ScheduleBlogRequest) ProtoMessage()    {}
+func (*ScheduleBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{23}
+}
+
+func (m *ScheduleBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleBlogRequest.Unmarshal(m, b)
+}
+func (m *ScheduleBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *ScheduleBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleBlogRequest.Merge(m, src)
+}
+func (m *ScheduleBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_ScheduleBlogRequest.Size(m)
+}
+func (m *ScheduleBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleBlogRequest proto.InternalMessageInfo
+
+func (m *ScheduleBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ScheduleBlogRequest) GetPublishAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.PublishAt
+	}
+	return nil
+}
+
+func (m *ScheduleBlogRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *ScheduleBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+type ScheduleBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScheduleBlogResponse) Reset()         { *m = ScheduleBlogResponse{} }
+func (m *ScheduleBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*ScheduleBlogResponse) ProtoMessage()    {}
+func (*ScheduleBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{24}
+}
+
+func (m *ScheduleBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleBlogResponse.Unmarshal(m, b)
+}
+func (m *ScheduleBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *ScheduleBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleBlogResponse.Merge(m, src)
+}
+func (m *ScheduleBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_ScheduleBlogResponse.Size(m)
+}
+func (m *ScheduleBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleBlogResponse proto.InternalMessageInfo
+
+func (m *ScheduleBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+// from PUBLISHED
+type ArchiveBlogRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Etag                 string   `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	Editor               string   `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveBlogRequest) Reset()         { *m = ArchiveBlogRequest{} }
+func (m *ArchiveBlogRequest) String() string { return proto.CompactTextString(m) }
+func (*ArchiveBlogRequest) ProtoMessage()    {}
+func (*ArchiveBlogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{25}
+}
+
+func (m *ArchiveBlogRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveBlogRequest.Unmarshal(m, b)
+}
+func (m *ArchiveBlogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveBlogRequest.Marshal(b, m, deterministic)
+}
+func (m *ArchiveBlogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveBlogRequest.Merge(m, src)
+}
+func (m *ArchiveBlogRequest) XXX_Size() int {
+	return xxx_messageInfo_ArchiveBlogRequest.Size(m)
+}
+func (m *ArchiveBlogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveBlogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveBlogRequest proto.InternalMessageInfo
+
+func (m *ArchiveBlogRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ArchiveBlogRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *ArchiveBlogRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+type ArchiveBlogResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchiveBlogResponse) Reset()         { *m = ArchiveBlogResponse{} }
+func (m *ArchiveBlogResponse) String() string { return proto.CompactTextString(m) }
+func (*ArchiveBlogResponse) ProtoMessage()    {}
+func (*ArchiveBlogResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{26}
+}
+
+func (m *ArchiveBlogResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchiveBlogResponse.Unmarshal(m, b)
+}
+func (m *ArchiveBlogResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchiveBlogResponse.Marshal(b, m, deterministic)
+}
+func (m *ArchiveBlogResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchiveBlogResponse.Merge(m, src)
+}
+func (m *ArchiveBlogResponse) XXX_Size() int {
+	return xxx_messageInfo_ArchiveBlogResponse.Size(m)
+}
+func (m *ArchiveBlogResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchiveBlogResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchiveBlogResponse proto.InternalMessageInfo
+
+func (m *ArchiveBlogResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+type ListBlogRevisionsRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// 20 when 0
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRevisionsRequest) Reset()         { *m = ListBlogRevisionsRequest{} }
+func (m *ListBlogRevisionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRevisionsRequest) ProtoMessage()    {}
+func (*ListBlogRevisionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{27}
+}
+
+func (m *ListBlogRevisionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Unmarshal(m, b)
+}
+func (m *ListBlogRevisionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBlogRevisionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRevisionsRequest.Merge(m, src)
+}
+func (m *ListBlogRevisionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRevisionsRequest.Size(m)
+}
+func (m *ListBlogRevisionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRevisionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRevisionsRequest proto.InternalMessageInfo
+
+func (m *ListBlogRevisionsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ListBlogRevisionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListBlogRevisionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListBlogRevisionsResponse struct {
+	Revisions []*BlogRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListBlogRevisionsResponse) Reset()         { *m = ListBlogRevisionsResponse{} }
+func (m *ListBlogRevisionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBlogRevisionsResponse) ProtoMessage()    {}
+func (*ListBlogRevisionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{28}
+}
+
+func (m *ListBlogRevisionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Unmarshal(m, b)
+}
+func (m *ListBlogRevisionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBlogRevisionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBlogRevisionsResponse.Merge(m, src)
+}
+func (m *ListBlogRevisionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBlogRevisionsResponse.Size(m)
+}
+func (m *ListBlogRevisionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBlogRevisionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBlogRevisionsResponse proto.InternalMessageInfo
+
+func (m *ListBlogRevisionsResponse) GetRevisions() []*BlogRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+func (m *ListBlogRevisionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GetBlogRevisionRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Number               int64    `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlogRevisionRequest) Reset()         { *m = GetBlogRevisionRequest{} }
+func (m *GetBlogRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlogRevisionRequest) ProtoMessage()    {}
+func (*GetBlogRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{29}
+}
+
+func (m *GetBlogRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogRevisionRequest.Unmarshal(m, b)
+}
+func (m *GetBlogRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogRevisionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlogRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogRevisionRequest.Merge(m, src)
+}
+func (m *GetBlogRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlogRevisionRequest.Size(m)
+}
+func (m *GetBlogRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlogRevisionRequest proto.InternalMessageInfo
+
+func (m *GetBlogRevisionRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *GetBlogRevisionRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type GetBlogRevisionResponse struct {
+	Revision             *BlogRevision `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetBlogRevisionResponse) Reset()         { *m = GetBlogRevisionResponse{} }
+func (m *GetBlogRevisionResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlogRevisionResponse) ProtoMessage()    {}
+func (*GetBlogRevisionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{30}
+}
+
+func (m *GetBlogRevisionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlogRevisionResponse.Unmarshal(m, b)
+}
+func (m *GetBlogRevisionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlogRevisionResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlogRevisionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlogRevisionResponse.Merge(m, src)
+}
+func (m *GetBlogRevisionResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlogRevisionResponse.Size(m)
+}
+func (m *GetBlogRevisionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlogRevisionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlogRevisionResponse proto.InternalMessageInfo
+
+func (m *GetBlogRevisionResponse) GetRevision() *BlogRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+type RestoreBlogRevisionRequest struct {
+	BlogId string `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	Number int64  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// recorded in the new revision, the author of the blog when empty
+	Editor string `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	// fails with ABORTED unless the blog is at this etag, when set
+	Etag                 string   `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBlogRevisionRequest) Reset()         { *m = RestoreBlogRevisionRequest{} }
+func (m *RestoreBlogRevisionRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogRevisionRequest) ProtoMessage()    {}
+func (*RestoreBlogRevisionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{31}
+}
+
+func (m *RestoreBlogRevisionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogRevisionRequest.Unmarshal(m, b)
+}
+func (m *RestoreBlogRevisionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogRevisionRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreBlogRevisionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogRevisionRequest.Merge(m, src)
+}
+func (m *RestoreBlogRevisionRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogRevisionRequest.Size(m)
+}
+func (m *RestoreBlogRevisionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogRevisionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBlogRevisionRequest proto.InternalMessageInfo
+
+func (m *RestoreBlogRevisionRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *RestoreBlogRevisionRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *RestoreBlogRevisionRequest) GetEditor() string {
+	if m != nil {
+		return m.Editor
+	}
+	return ""
+}
+
+func (m *RestoreBlogRevisionRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type RestoreBlogRevisionResponse struct {
+	Blog                 *Blog         `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	Revision             *BlogRevision `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RestoreBlogRevisionResponse) Reset()         { *m = RestoreBlogRevisionResponse{} }
+func (m *RestoreBlogRevisionResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreBlogRevisionResponse) ProtoMessage()    {}
+func (*RestoreBlogRevisionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{32}
+}
+
+func (m *RestoreBlogRevisionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBlogRevisionResponse.Unmarshal(m, b)
+}
+func (m *RestoreBlogRevisionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBlogRevisionResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreBlogRevisionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBlogRevisionResponse.Merge(m, src)
+}
+func (m *RestoreBlogRevisionResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreBlogRevisionResponse.Size(m)
+}
+func (m *RestoreBlogRevisionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBlogRevisionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBlogRevisionResponse proto.InternalMessageInfo
+
+func (m *RestoreBlogRevisionResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *RestoreBlogRevisionResponse) GetRevision() *BlogRevision {
+	if m != nil {
+		return m.Revision
+	}
+	return nil
+}
+
+type DiffBlogRevisionsRequest struct {
+	BlogId               string   `protobuf:"bytes,1,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	FromNumber           int64    `protobuf:"varint,2,opt,name=from_number,json=fromNumber,proto3" json:"from_number,omitempty"`
+	ToNumber             int64    `protobuf:"varint,3,opt,name=to_number,json=toNumber,proto3" json:"to_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiffBlogRevisionsRequest) Reset()         { *m = DiffBlogRevisionsRequest{} }
+func (m *DiffBlogRevisionsRequest) String() string { return proto.CompactTextString(m) }
+func (*DiffBlogRevisionsRequest) ProtoMessage()    {}
+func (*DiffBlogRevisionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{33}
+}
+
+func (m *DiffBlogRevisionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffBlogRevisionsRequest.Unmarshal(m, b)
+}
+func (m *DiffBlogRevisionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffBlogRevisionsRequest.Marshal(b, m, deterministic)
+}
+func (m *DiffBlogRevisionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffBlogRevisionsRequest.Merge(m, src)
+}
+func (m *DiffBlogRevisionsRequest) XXX_Size() int {
+	return xxx_messageInfo_DiffBlogRevisionsRequest.Size(m)
+}
+func (m *DiffBlogRevisionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffBlogRevisionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffBlogRevisionsRequest proto.InternalMessageInfo
+
+func (m *DiffBlogRevisionsRequest) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *DiffBlogRevisionsRequest) GetFromNumber() int64 {
+	if m != nil {
+		return m.FromNumber
+	}
+	return 0
+}
+
+func (m *DiffBlogRevisionsRequest) GetToNumber() int64 {
+	if m != nil {
+		return m.ToNumber
+	}
+	return 0
+}
+
+type DiffBlogRevisionsResponse struct {
+	// unified diffs, line by line, empty when nothing changed
+	TitleDiff            string   `protobuf:"bytes,1,opt,name=title_diff,json=titleDiff,proto3" json:"title_diff,omitempty"`
+	ContentDiff          string   `protobuf:"bytes,2,opt,name=content_diff,json=contentDiff,proto3" json:"content_diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiffBlogRevisionsResponse) Reset()         { *m = DiffBlogRevisionsResponse{} }
+func (m *DiffBlogRevisionsResponse) String() string { return proto.CompactTextString(m) }
+func (*DiffBlogRevisionsResponse) ProtoMessage()    {}
+func (*DiffBlogRevisionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{34}
+}
+
+func (m *DiffBlogRevisionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiffBlogRevisionsResponse.Unmarshal(m, b)
+}
+func (m *DiffBlogRevisionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiffBlogRevisionsResponse.Marshal(b, m, deterministic)
+}
+func (m *DiffBlogRevisionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiffBlogRevisionsResponse.Merge(m, src)
+}
+func (m *DiffBlogRevisionsResponse) XXX_Size() int {
+	return xxx_messageInfo_DiffBlogRevisionsResponse.Size(m)
+}
+func (m *DiffBlogRevisionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiffBlogRevisionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiffBlogRevisionsResponse proto.InternalMessageInfo
+
+func (m *DiffBlogRevisionsResponse) GetTitleDiff() string {
+	if m != nil {
+		return m.TitleDiff
+	}
+	return ""
+}
+
+func (m *DiffBlogRevisionsResponse) GetContentDiff() string {
+	if m != nil {
+		return m.ContentDiff
+	}
+	return ""
+}
+
+type WatchBlogsRequest struct {
+	// resume_token of the last event received, the changes made from now on
+	// are sent when empty
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// only the changes of the blogs of this author, when set
+	AuthorId             string   `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchBlogsRequest) Reset()         { *m = WatchBlogsRequest{} }
+func (m *WatchBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchBlogsRequest) ProtoMessage()    {}
+func (*WatchBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{35}
+}
+
+func (m *WatchBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchBlogsRequest.Unmarshal(m, b)
+}
+func (m *WatchBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchBlogsRequest.Merge(m, src)
+}
+func (m *WatchBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchBlogsRequest.Size(m)
+}
+func (m *WatchBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchBlogsRequest proto.InternalMessageInfo
+
+func (m *WatchBlogsRequest) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *WatchBlogsRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+type WatchBlogsResponse struct {
+	Type WatchBlogsResponse_Type `protobuf:"varint,1,opt,name=type,proto3,enum=blog.WatchBlogsResponse_Type" json:"type,omitempty"`
+	Blog *Blog                   `protobuf:"bytes,2,opt,name=blog,proto3" json:"blog,omitempty"`
+	// sent back in a WatchBlogsRequest to get the changes after this one
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchBlogsResponse) Reset()         { *m = WatchBlogsResponse{} }
+func (m *WatchBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchBlogsResponse) ProtoMessage()    {}
+func (*WatchBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{36}
+}
+
+func (m *WatchBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchBlogsResponse.Unmarshal(m, b)
+}
+func (m *WatchBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchBlogsResponse.Merge(m, src)
+}
+func (m *WatchBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchBlogsResponse.Size(m)
+}
+func (m *WatchBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchBlogsResponse proto.InternalMessageInfo
+
+func (m *WatchBlogsResponse) GetType() WatchBlogsResponse_Type {
+	if m != nil {
+		return m.Type
+	}
+	return WatchBlogsResponse_TYPE_UNSPECIFIED
+}
+
+func (m *WatchBlogsResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *WatchBlogsResponse) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+type ImportBlogsRequest struct {
+	// created with its status and times, they are the ones of a new blog when
+	// not set: PUBLISHED, published when created. The id, revision, etag and
+	// deleted_at are ignored.
+	Blog *Blog `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	// unique, a blog imported again with the same one fails with
+	// ALREADY_EXISTS, unless upsert is set
+	ExternalId string `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// read from the first request only: nothing is written, the results
+	// tell what would be done
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// read from the first request only: the blog imported before with the
+	// external_id is updated with a new revision, it keeps its creation time
+	Upsert               bool     `protobuf:"varint,4,opt,name=upsert,proto3" json:"upsert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBlogsRequest) Reset()         { *m = ImportBlogsRequest{} }
+func (m *ImportBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportBlogsRequest) ProtoMessage()    {}
+func (*ImportBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{37}
+}
+
+func (m *ImportBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBlogsRequest.Unmarshal(m, b)
+}
+func (m *ImportBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBlogsRequest.Merge(m, src)
+}
+func (m *ImportBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportBlogsRequest.Size(m)
+}
+func (m *ImportBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBlogsRequest proto.InternalMessageInfo
+
+func (m *ImportBlogsRequest) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func (m *ImportBlogsRequest) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
+func (m *ImportBlogsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *ImportBlogsRequest) GetUpsert() bool {
+	if m != nil {
+		return m.Upsert
+	}
+	return false
+}
+
+type ImportBlogsResult struct {
+	Index      int32                    `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ExternalId string                   `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Action     ImportBlogsResult_Action `protobuf:"varint,3,opt,name=action,proto3,enum=blog.ImportBlogsResult_Action" json:"action,omitempty"`
+	BlogId     string                   `protobuf:"bytes,4,opt,name=blog_id,json=blogId,proto3" json:"blog_id,omitempty"`
+	// when FAILED
+	ErrorCode            string   `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorReason          string   `protobuf:"bytes,6,opt,name=error_reason,json=errorReason,proto3" json:"error_reason,omitempty"`
+	ErrorMessage         string   `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBlogsResult) Reset()         { *m = ImportBlogsResult{} }
+func (m *ImportBlogsResult) String() string { return proto.CompactTextString(m) }
+func (*ImportBlogsResult) ProtoMessage()    {}
+func (*ImportBlogsResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{38}
+}
+
+func (m *ImportBlogsResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBlogsResult.Unmarshal(m, b)
+}
+func (m *ImportBlogsResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBlogsResult.Marshal(b, m, deterministic)
+}
+func (m *ImportBlogsResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBlogsResult.Merge(m, src)
+}
+func (m *ImportBlogsResult) XXX_Size() int {
+	return xxx_messageInfo_ImportBlogsResult.Size(m)
+}
+func (m *ImportBlogsResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBlogsResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBlogsResult proto.InternalMessageInfo
+
+func (m *ImportBlogsResult) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ImportBlogsResult) GetExternalId() string {
+	if m != nil {
+		return m.ExternalId
+	}
+	return ""
+}
+
+func (m *ImportBlogsResult) GetAction() ImportBlogsResult_Action {
+	if m != nil {
+		return m.Action
+	}
+	return ImportBlogsResult_ACTION_UNSPECIFIED
+}
+
+func (m *ImportBlogsResult) GetBlogId() string {
+	if m != nil {
+		return m.BlogId
+	}
+	return ""
+}
+
+func (m *ImportBlogsResult) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+func (m *ImportBlogsResult) GetErrorReason() string {
+	if m != nil {
+		return m.ErrorReason
+	}
+	return ""
+}
+
+func (m *ImportBlogsResult) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+type ImportBlogsResponse struct {
+	Results              []*ImportBlogsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created              int32                `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Updated              int32                `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed               int32                `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ImportBlogsResponse) Reset()         { *m = ImportBlogsResponse{} }
+func (m *ImportBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportBlogsResponse) ProtoMessage()    {}
+func (*ImportBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{39}
+}
+
+func (m *ImportBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBlogsResponse.Unmarshal(m, b)
+}
+func (m *ImportBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBlogsResponse.Merge(m, src)
+}
+func (m *ImportBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportBlogsResponse.Size(m)
+}
+func (m *ImportBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBlogsResponse proto.InternalMessageInfo
+
+func (m *ImportBlogsResponse) GetResults() []*ImportBlogsResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *ImportBlogsResponse) GetCreated() int32 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ImportBlogsResponse) GetUpdated() int32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *ImportBlogsResponse) GetFailed() int32 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+type ExportBlogsRequest struct {
+	// filters, all of them must match
+	AuthorId      string               `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Tag           string               `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	CreatedAfter  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// any status when unspecified
+	Status               Blog_Status `protobuf:"varint,5,opt,name=status,proto3,enum=blog.Blog_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ExportBlogsRequest) Reset()         { *m = ExportBlogsRequest{} }
+func (m *ExportBlogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportBlogsRequest) ProtoMessage()    {}
+func (*ExportBlogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{40}
+}
+
+func (m *ExportBlogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportBlogsRequest.Unmarshal(m, b)
+}
+func (m *ExportBlogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportBlogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportBlogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportBlogsRequest.Merge(m, src)
+}
+func (m *ExportBlogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportBlogsRequest.Size(m)
+}
+func (m *ExportBlogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportBlogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportBlogsRequest proto.InternalMessageInfo
+
+func (m *ExportBlogsRequest) GetAuthorId() string {
+	if m != nil {
+		return m.AuthorId
+	}
+	return ""
+}
+
+func (m *ExportBlogsRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *ExportBlogsRequest) GetCreatedAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAfter
+	}
+	return nil
+}
+
+func (m *ExportBlogsRequest) GetCreatedBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return nil
+}
+
+func (m *ExportBlogsRequest) GetStatus() Blog_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Blog_STATUS_UNSPECIFIED
+}
+
+type ExportBlogsResponse struct {
+	Blog                 *Blog    `protobuf:"bytes,1,opt,name=blog,proto3" json:"blog,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportBlogsResponse) Reset()         { *m = ExportBlogsResponse{} }
+func (m *ExportBlogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportBlogsResponse) ProtoMessage()    {}
+func (*ExportBlogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a4b0406114889fe6, []int{41}
+}
+
+func (m *ExportBlogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportBlogsResponse.Unmarshal(m, b)
+}
+func (m *ExportBlogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportBlogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportBlogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportBlogsResponse.Merge(m, src)
+}
+func (m *ExportBlogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportBlogsResponse.Size(m)
+}
+func (m *ExportBlogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportBlogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportBlogsResponse proto.InternalMessageInfo
+
+func (m *ExportBlogsResponse) GetBlog() *Blog {
+	if m != nil {
+		return m.Blog
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("blog.Blog_Status", Blog_Status_name, Blog_Status_value)
+	proto.RegisterEnum("blog.ListBlogsRequest_Order", ListBlogsRequest_Order_name, ListBlogsRequest_Order_value)
+	proto.RegisterEnum("blog.WatchBlogsResponse_Type", WatchBlogsResponse_Type_name, WatchBlogsResponse_Type_value)
+	proto.RegisterEnum("blog.ImportBlogsResult_Action", ImportBlogsResult_Action_name, ImportBlogsResult_Action_value)
+	proto.RegisterType((*Blog)(nil), "blog.Blog")
+	proto.RegisterType((*CreateBlogRequest)(nil), "blog.CreateBlogRequest")
+	proto.RegisterType((*CreateBlogResponse)(nil), "blog.CreateBlogResponse")
+	proto.RegisterType((*ReadBlogRequest)(nil), "blog.ReadBlogRequest")
+	proto.RegisterType((*ReadBlogResponse)(nil), "blog.ReadBlogResponse")
+	proto.RegisterType((*SearchBlogsRequest)(nil), "blog.SearchBlogsRequest")
+	proto.RegisterType((*Highlight)(nil), "blog.Highlight")
+	proto.RegisterType((*SearchBlogsResponse)(nil), "blog.SearchBlogsResponse")
+	proto.RegisterType((*ListBlogsRequest)(nil), "blog.ListBlogsRequest")
+	proto.RegisterType((*ListBlogsResponse)(nil), "blog.ListBlogsResponse")
+	proto.RegisterType((*UpdateBlogRequest)(nil), "blog.UpdateBlogRequest")
+	proto.RegisterType((*UpdateBlogResponse)(nil), "blog.UpdateBlogResponse")
+	proto.RegisterType((*DeleteBlogRequest)(nil), "blog.DeleteBlogRequest")
+	proto.RegisterType((*DeleteBlogResponse)(nil), "blog.DeleteBlogResponse")
+	proto.RegisterType((*ListDeletedBlogsRequest)(nil), "blog.ListDeletedBlogsRequest")
+	proto.RegisterType((*ListDeletedBlogsResponse)(nil), "blog.ListDeletedBlogsResponse")
+	proto.RegisterType((*RestoreBlogRequest)(nil), "blog.RestoreBlogRequest")
+	proto.RegisterType((*RestoreBlogResponse)(nil), "blog.RestoreBlogResponse")
+	proto.RegisterType((*BlogRevision)(nil), "blog.BlogRevision")
+	proto.RegisterType((*PublishBlogRequest)(nil), "blog.PublishBlogRequest")
+	proto.RegisterType((*PublishBlogResponse)(nil), "blog.PublishBlogResponse")
+	proto.RegisterType((*UnpublishBlogRequest)(nil), "blog.UnpublishBlogRequest")
+	proto.RegisterType((*UnpublishBlogResponse)(nil), "blog.UnpublishBlogResponse")
+	proto.RegisterType((*ScheduleBlogRequest)(nil), "blog.ScheduleBlogRequest")
+	proto.RegisterType((*ScheduleBlogResponse)(nil), "blog.ScheduleBlogResponse")
+	proto.RegisterType((*ArchiveBlogRequest)(nil), "blog.ArchiveBlogRequest")
+	proto.RegisterType((*ArchiveBlogResponse)(nil), "blog.ArchiveBlogResponse")
+	proto.RegisterType((*ListBlogRevisionsRequest)(nil), "blog.ListBlogRevisionsRequest")
+	proto.RegisterType((*ListBlogRevisionsResponse)(nil), "blog.ListBlogRevisionsResponse")
+	proto.RegisterType((*GetBlogRevisionRequest)(nil), "blog.GetBlogRevisionRequest")
+	proto.RegisterType((*GetBlogRevisionResponse)(nil), "blog.GetBlogRevisionResponse")
+	proto.RegisterType((*RestoreBlogRevisionRequest)(nil), "blog.RestoreBlogRevisionRequest")
+	proto.RegisterType((*RestoreBlogRevisionResponse)(nil), "blog.RestoreBlogRevisionResponse")
+	proto.RegisterType((*DiffBlogRevisionsRequest)(nil), "blog.DiffBlogRevisionsRequest")
+	proto.RegisterType((*DiffBlogRevisionsResponse)(nil), "blog.DiffBlogRevisionsResponse")
+	proto.RegisterType((*WatchBlogsRequest)(nil), "blog.WatchBlogsRequest")
+	proto.RegisterType((*WatchBlogsResponse)(nil), "blog.WatchBlogsResponse")
+	proto.RegisterType((*ImportBlogsRequest)(nil), "blog.ImportBlogsRequest")
+	proto.RegisterType((*ImportBlogsResult)(nil), "blog.ImportBlogsResult")
+	proto.RegisterType((*ImportBlogsResponse)(nil), "blog.ImportBlogsResponse")
+	proto.RegisterType((*ExportBlogsRequest)(nil), "blog.ExportBlogsRequest")
+	proto.RegisterType((*ExportBlogsResponse)(nil), "blog.ExportBlogsResponse")
+}
+
+func init() { proto.RegisterFile("blog/blogpb/blog.proto", fileDescriptor_a4b0406114889fe6) }
+
+var fileDescriptor_a4b0406114889fe6 = []byte{
+	// 2138 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x39, 0x4b, 0x6f, 0xe3, 0xc8,
+	0xd1, 0x43, 0x51, 0x92, 0xc5, 0x92, 0x1f, 0x72, 0xdb, 0x6b, 0x53, 0x9c, 0xcf, 0xb6, 0x86, 0x1f,
+	0x76, 0xe1, 0xcd, 0x43, 0x9e, 0x78, 0x5f, 0x18, 0x6c, 0x82, 0x89, 0x6c, 0xc9, 0xb1, 0x10, 0xaf,
+	0xc7, 0x69, 0xc9, 0x19, 0xec, 0x02, 0x0b, 0x85, 0x16, 0x5b, 0x12, 0x61, 0x49, 0xd4, 0x92, 0xd4,
+	0xc0, 0x5e, 0xe4, 0xe4, 0x63, 0x80, 0x20, 0x80, 0x11, 0x24, 0x39, 0xe6, 0x92, 0x63, 0xfe, 0x47,
+	0x90, 0x4b, 0xfe, 0x45, 0x7e, 0x40, 0x90, 0x43, 0x8e, 0x41, 0x3f, 0x28, 0x92, 0x22, 0x65, 0x69,
+	0x26, 0x99, 0x39, 0x64, 0x0e, 0x23, 0x76, 0x55, 0x75, 0xbd, 0xbb, 0xba, 0xba, 0x0c, 0x5b, 0x57,
+	0x7d, 0xbb, 0x7b, 0x40, 0xff, 0x1b, 0x5d, 0xb1, 0x9f, 0xf2, 0xc8, 0xb1, 0x3d, 0x1b, 0xa5, 0xe9,
+	0xb7, 0x56, 0xea, 0xda, 0x76, 0xb7, 0x4f, 0x0e, 0x18, 0xec, 0x6a, 0xdc, 0x39, 0xe8, 0x58, 0xa4,
+	0x6f, 0xb6, 0x06, 0x86, 0x7b, 0xcd, 0xe9, 0xb4, 0xbd, 0x69, 0x0a, 0xcf, 0x1a, 0x10, 0xd7, 0x33,
+	0x06, 0x23, 0x41, 0xb0, 0xff, 0xca, 0xe8, 0x5b, 0xa6, 0xe1, 0x59, 0xf6, 0xf0, 0x20, 0xf8, 0x1c,
+	0x5d, 0x85, 0x16, 0x9c, 0x52, 0xff, 0x5d, 0x06, 0xd2, 0x47, 0x7d, 0xbb, 0x8b, 0x56, 0x21, 0x65,
+	0x99, 0xaa, 0x54, 0x92, 0xf6, 0x15, 0x9c, 0xb2, 0x4c, 0xf4, 0x3e, 0x28, 0xc6, 0xd8, 0xeb, 0xd9,
+	0x4e, 0xcb, 0x32, 0xd5, 0x14, 0x05, 0x1f, 0xe5, 0xee, 0xef, 0x8a, 0xe9, 0x9c, 0xa4, 0x9a, 0x38,
+	0xc7, 0x51, 0x75, 0x13, 0xed, 0x41, 0xc6, 0xb3, 0xbc, 0x3e, 0x51, 0x65, 0x46, 0xa2, 0xdc, 0xdf,
+	0x15, 0x33, 0x39, 0x49, 0xfd, 0x8b, 0x84, 0x39, 0x1c, 0xe9, 0xb0, 0xd4, 0xb6, 0x87, 0x1e, 0x19,
+	0x7a, 0x6a, 0x3a, 0xe0, 0xa2, 0xfe, 0xf1, 0xd7, 0x59, 0xec, 0x23, 0x10, 0x82, 0xb4, 0x67, 0x74,
+	0x5d, 0x35, 0x53, 0x92, 0xf7, 0x15, 0xcc, 0xbe, 0xd1, 0x33, 0x80, 0xb6, 0x43, 0x0c, 0x8f, 0x98,
+	0x2d, 0xc3, 0x53, 0xb3, 0x25, 0x69, 0x3f, 0x7f, 0xa8, 0x95, 0xb9, 0xe1, 0x65, 0xdf, 0xf0, 0x72,
+	0xd3, 0x37, 0x1c, 0x2b, 0x82, 0xba, 0xe2, 0xd1, 0xad, 0xe3, 0x91, 0xe9, 0x6f, 0x5d, 0x9a, 0xbf,
+	0x55, 0x50, 0x57, 0x3c, 0xa4, 0x41, 0xce, 0x21, 0xaf, 0x2c, 0xd7, 0xb2, 0x87, 0x6a, 0xae, 0x24,
+	0xed, 0xcb, 0x78, 0xb2, 0xa6, 0x5a, 0x12, 0xcf, 0xe8, 0xaa, 0x0a, 0xf3, 0x11, 0xfb, 0x46, 0x1f,
+	0x42, 0xd6, 0xf5, 0x0c, 0x6f, 0xec, 0xaa, 0x50, 0x92, 0xf6, 0x57, 0x0f, 0xd7, 0xcb, 0x2c, 0x9c,
+	0xd4, 0xa3, 0xe5, 0x06, 0x43, 0x60, 0x41, 0x40, 0xb5, 0x1a, 0x8d, 0xaf, 0xfa, 0x96, 0xdb, 0xa3,
+	0x5a, 0xe5, 0xe7, 0x6b, 0x25, 0xa8, 0x2b, 0x1e, 0xfa, 0x11, 0x2c, 0x8b, 0x05, 0x37, 0x69, 0x79,
+	0xee, 0xe6, 0xfc, 0x84, 0x9e, 0xfb, 0xc3, 0x24, 0x7d, 0x22, 0xfc, 0xb1, 0x32, 0x5f, 0xb2, 0xa0,
+	0xae, 0x78, 0x68, 0x0f, 0xf2, 0xe4, 0xc6, 0x23, 0xce, 0xd0, 0xe8, 0xd3, 0x3c, 0x58, 0x65, 0xa6,
+	0x83, 0x0f, 0xaa, 0x9b, 0xfa, 0x4b, 0xc8, 0x72, 0x3b, 0xd1, 0x16, 0xa0, 0x46, 0xb3, 0xd2, 0xbc,
+	0x6c, 0xb4, 0x2e, 0xcf, 0x1b, 0x17, 0xb5, 0xe3, 0xfa, 0x49, 0xbd, 0x56, 0x2d, 0x3c, 0x42, 0x0a,
+	0x64, 0xaa, 0xb8, 0x72, 0xd2, 0x2c, 0x48, 0x68, 0x05, 0x94, 0xc6, 0xf1, 0x69, 0xad, 0x7a, 0x79,
+	0x56, 0xab, 0x16, 0x52, 0x74, 0x79, 0x71, 0x79, 0x74, 0x56, 0x6f, 0x9c, 0xd6, 0xaa, 0x05, 0x19,
+	0x2d, 0x43, 0xae, 0x82, 0x8f, 0x4f, 0xeb, 0x3f, 0xaf, 0x55, 0x0b, 0x69, 0x7d, 0x00, 0xeb, 0xc7,
+	0x2c, 0xa2, 0xd4, 0x97, 0x98, 0x7c, 0x33, 0x26, 0xae, 0x87, 0x3e, 0x00, 0x76, 0x44, 0x58, 0x9a,
+	0xe6, 0x0f, 0x21, 0x70, 0xf6, 0x51, 0xf6, 0xfe, 0xae, 0x98, 0xca, 0x49, 0x98, 0xe1, 0xd1, 0x53,
+	0x58, 0xb3, 0x4c, 0x32, 0x18, 0xd9, 0x1e, 0x19, 0xb6, 0x6f, 0x5b, 0xd7, 0xe4, 0x56, 0xa4, 0xf0,
+	0xd2, 0xfd, 0x5d, 0x51, 0xa6, 0xd9, 0xb9, 0x1a, 0xc2, 0xff, 0x94, 0xdc, 0xea, 0x1f, 0x03, 0x0a,
+	0x8b, 0x73, 0x47, 0xf6, 0xd0, 0x25, 0x68, 0x77, 0x96, 0x3c, 0x2e, 0x47, 0xbf, 0x86, 0x35, 0x4c,
+	0x0c, 0x33, 0xac, 0xe2, 0x1e, 0x2c, 0x51, 0x54, 0xcb, 0x3f, 0x4c, 0x13, 0xcd, 0xb2, 0x14, 0x5c,
+	0x37, 0xd1, 0x67, 0xa0, 0x38, 0xc4, 0xe0, 0xe7, 0x59, 0x4d, 0xcd, 0x08, 0xc6, 0x09, 0x3d, 0xf2,
+	0x5f, 0x18, 0xee, 0x35, 0xcd, 0x3f, 0x83, 0x7d, 0xe9, 0x87, 0x50, 0x08, 0x84, 0x2d, 0xa8, 0x60,
+	0x1b, 0x50, 0x83, 0x18, 0x4e, 0xbb, 0x47, 0x61, 0x6e, 0xa0, 0x63, 0xe6, 0x9b, 0x31, 0x71, 0x6e,
+	0x55, 0x29, 0x72, 0x68, 0xff, 0x29, 0x63, 0x0e, 0x47, 0xdf, 0x83, 0x4c, 0xdf, 0x1a, 0x58, 0x1e,
+	0xd3, 0x2f, 0x73, 0xb4, 0x75, 0x7f, 0x57, 0x44, 0x1f, 0x3e, 0x12, 0xff, 0x9e, 0xf1, 0x9f, 0x2f,
+	0x7f, 0x8c, 0x39, 0x91, 0xfe, 0x39, 0x28, 0xa7, 0x56, 0xb7, 0xd7, 0xb7, 0xba, 0x3d, 0x0f, 0x6d,
+	0x42, 0x86, 0xd5, 0x2b, 0x51, 0x4a, 0xf8, 0x02, 0xa9, 0xb0, 0xe4, 0x0e, 0xad, 0xd1, 0x88, 0x70,
+	0x96, 0x0a, 0xf6, 0x97, 0xfa, 0x2f, 0x61, 0x23, 0xa2, 0xe1, 0x62, 0x86, 0x51, 0x31, 0x6e, 0xdb,
+	0x76, 0x08, 0x63, 0x27, 0x61, 0xbe, 0x40, 0x07, 0x00, 0x3d, 0x5f, 0x13, 0x57, 0x95, 0x4b, 0xf2,
+	0x7e, 0xfe, 0x70, 0x8d, 0xef, 0x9d, 0x68, 0x88, 0x43, 0x24, 0xfa, 0x9f, 0x64, 0x28, 0x9c, 0x59,
+	0xae, 0x17, 0x71, 0xcf, 0xe3, 0x70, 0xe9, 0xe3, 0x66, 0x04, 0x05, 0xef, 0x39, 0xac, 0x4c, 0xea,
+	0x52, 0xc7, 0x23, 0x8e, 0x9a, 0x9a, 0x7b, 0x9e, 0x96, 0xfd, 0xd2, 0x44, 0xe9, 0x51, 0x05, 0x56,
+	0x7d, 0x06, 0x57, 0xa4, 0x63, 0x3b, 0xbc, 0x74, 0x3e, 0xcc, 0xc1, 0x17, 0x79, 0xc4, 0x36, 0xa0,
+	0x02, 0xc8, 0xb4, 0x10, 0xb1, 0x7a, 0x8a, 0xe9, 0x27, 0x3a, 0x84, 0x8c, 0xed, 0x98, 0xc4, 0x51,
+	0x33, 0xac, 0x0c, 0xfd, 0x1f, 0xb7, 0x79, 0xda, 0xb2, 0xf2, 0x0b, 0x4a, 0x83, 0x39, 0x29, 0xfa,
+	0x08, 0x94, 0x91, 0xd1, 0x25, 0x2d, 0xd7, 0xfa, 0x96, 0xa8, 0xd9, 0x07, 0x03, 0x9d, 0xa3, 0x84,
+	0x0d, 0xeb, 0x5b, 0x82, 0x76, 0x00, 0xd8, 0x26, 0xcf, 0xbe, 0x26, 0x43, 0x56, 0x5b, 0x15, 0xcc,
+	0xd8, 0x34, 0x29, 0x20, 0x54, 0x0f, 0x73, 0x73, 0xea, 0xa1, 0xfe, 0x5d, 0xc8, 0x30, 0x75, 0x50,
+	0x01, 0x96, 0xcf, 0x6b, 0x2f, 0x6b, 0x8d, 0x66, 0xeb, 0xa4, 0x8e, 0x1b, 0xcd, 0xc2, 0x23, 0x0a,
+	0x79, 0x71, 0x56, 0x0d, 0x20, 0x92, 0xfe, 0x35, 0xac, 0x87, 0x8c, 0x11, 0x39, 0x52, 0x82, 0x0c,
+	0xe5, 0xee, 0xaa, 0x52, 0x49, 0x9e, 0x4a, 0x12, 0x8e, 0x40, 0x1f, 0xc0, 0xda, 0x90, 0xdc, 0x78,
+	0xad, 0x90, 0xca, 0x3c, 0xfd, 0x56, 0x28, 0xf8, 0xc2, 0x57, 0x5b, 0xff, 0x83, 0x04, 0xeb, 0x97,
+	0xec, 0x12, 0x78, 0x93, 0x6a, 0xb3, 0x0b, 0x59, 0x62, 0x5a, 0x9e, 0xed, 0x88, 0x22, 0xc3, 0xb0,
+	0xaa, 0x89, 0x05, 0x14, 0x7d, 0x0e, 0x79, 0x7e, 0xc3, 0xf0, 0x33, 0x2f, 0xcf, 0x3d, 0xf3, 0xe2,
+	0xfa, 0x62, 0xa7, 0xfe, 0x63, 0x40, 0x61, 0xcd, 0x16, 0x3c, 0xf7, 0x3d, 0x58, 0xaf, 0xb2, 0x22,
+	0xfe, 0x5a, 0xa5, 0xc9, 0xbf, 0xe1, 0x52, 0xa1, 0x1b, 0x2e, 0x30, 0x4e, 0x4e, 0x32, 0x4e, 0xff,
+	0x3e, 0xa0, 0xb0, 0x24, 0xa1, 0xdf, 0xf6, 0x94, 0x28, 0x5f, 0x84, 0xfe, 0x2b, 0x09, 0xb6, 0x69,
+	0x24, 0xf9, 0x1e, 0x73, 0xf1, 0x73, 0x17, 0xc9, 0xd6, 0xd4, 0x1b, 0x65, 0xab, 0x3c, 0x95, 0xad,
+	0xba, 0x09, 0x6a, 0x5c, 0x97, 0xff, 0x7a, 0x72, 0x59, 0x80, 0x30, 0x71, 0x3d, 0xdb, 0x79, 0xfb,
+	0xc1, 0xf8, 0x04, 0x36, 0x22, 0xa2, 0x16, 0xcc, 0x96, 0x7f, 0xc9, 0xb0, 0xcc, 0x37, 0x88, 0x56,
+	0x67, 0x56, 0xf8, 0xd0, 0x16, 0x64, 0x87, 0xe3, 0xc1, 0x95, 0x28, 0x7b, 0x32, 0x16, 0xab, 0x68,
+	0xe8, 0xe4, 0xa9, 0xd0, 0x6d, 0xfa, 0x3d, 0x22, 0x2f, 0x58, 0x7c, 0x41, 0xaf, 0x04, 0xbf, 0x31,
+	0xcc, 0xf0, 0x2b, 0x61, 0xba, 0x1d, 0xcc, 0x86, 0xda, 0xc1, 0xad, 0x89, 0xe5, 0xbc, 0xe6, 0x88,
+	0xd5, 0x54, 0x9b, 0x98, 0x7b, 0x9d, 0x36, 0xf1, 0xff, 0x61, 0xc5, 0xe1, 0xce, 0x32, 0x5b, 0x1d,
+	0xc7, 0x1e, 0xb0, 0xc6, 0x4e, 0xc6, 0xcb, 0x3e, 0xf0, 0xc4, 0xb1, 0x07, 0xff, 0xeb, 0x0d, 0x1e,
+	0x4d, 0xce, 0x0b, 0xce, 0xe9, 0x5d, 0x24, 0x67, 0x44, 0xd4, 0xc2, 0x3d, 0xd6, 0xe6, 0xe5, 0x70,
+	0xf4, 0x8e, 0x74, 0xfc, 0x0c, 0xde, 0x9b, 0x12, 0xb6, 0xa0, 0x96, 0x7f, 0x96, 0x60, 0xa3, 0xd1,
+	0xee, 0x11, 0x73, 0xdc, 0x7f, 0xbd, 0x63, 0x5e, 0x89, 0x64, 0xcd, 0xdc, 0x66, 0x62, 0xb2, 0x3f,
+	0x94, 0x3d, 0xbe, 0xa1, 0x72, 0xa2, 0xa1, 0xe9, 0x44, 0x43, 0x3f, 0x85, 0xcd, 0xa8, 0xba, 0x0b,
+	0xda, 0x69, 0x01, 0xaa, 0x38, 0xed, 0x9e, 0xf5, 0xea, 0x9d, 0x14, 0xb3, 0x88, 0xa8, 0x05, 0x35,
+	0xfc, 0x8d, 0xc4, 0xab, 0x7a, 0xb8, 0xa0, 0xb9, 0x0b, 0x2b, 0xfa, 0x36, 0xae, 0x99, 0x31, 0x14,
+	0x13, 0x14, 0x12, 0xe6, 0x3c, 0xa5, 0xcf, 0x01, 0x01, 0x14, 0x77, 0x0d, 0x0a, 0xd9, 0x24, 0x50,
+	0x38, 0x20, 0x5a, 0xf8, 0xde, 0xf9, 0x05, 0x6c, 0xfd, 0x84, 0x44, 0xa4, 0x2e, 0xec, 0x85, 0xf7,
+	0xa3, 0x65, 0xfe, 0x68, 0xe5, 0xfe, 0xae, 0xa8, 0x3c, 0xf1, 0x5d, 0xe0, 0x57, 0x7d, 0xbd, 0x0e,
+	0xdb, 0x31, 0x09, 0xc2, 0xac, 0x72, 0xe8, 0x21, 0xcd, 0x23, 0x95, 0x64, 0xd5, 0x84, 0x86, 0x76,
+	0x60, 0x5a, 0xe4, 0xea, 0x7a, 0x2b, 0x1a, 0xcf, 0xcb, 0xb9, 0x49, 0x9e, 0xa6, 0x83, 0x3c, 0xd5,
+	0x07, 0xf0, 0x38, 0x51, 0xb3, 0x05, 0x5f, 0x2a, 0x61, 0x4f, 0xa4, 0x16, 0xf0, 0xc4, 0xef, 0x25,
+	0x50, 0xab, 0x56, 0xa7, 0xf3, 0x66, 0xf9, 0x5b, 0x86, 0x3c, 0xbd, 0xcb, 0x5a, 0x0f, 0x39, 0x03,
+	0x28, 0xc5, 0x39, 0x77, 0xc8, 0x77, 0x40, 0xf1, 0x6c, 0x9f, 0x5a, 0x4e, 0xa2, 0xce, 0x79, 0x36,
+	0xa7, 0xd5, 0xbf, 0x86, 0x62, 0x82, 0x62, 0xc2, 0x0d, 0x3b, 0x00, 0xec, 0x5e, 0x6f, 0x99, 0x56,
+	0xa7, 0x23, 0xba, 0x06, 0x85, 0x41, 0xe8, 0x1e, 0xf4, 0x04, 0x96, 0xc5, 0xf5, 0xce, 0x09, 0x78,
+	0xc6, 0xe6, 0x05, 0x8c, 0x92, 0xe8, 0x0d, 0x58, 0x7f, 0x69, 0x78, 0x53, 0x4f, 0xd5, 0x27, 0x40,
+	0xef, 0xe3, 0xf1, 0xc0, 0xcf, 0x74, 0xce, 0x38, 0xcf, 0x61, 0xfc, 0xcd, 0xf1, 0x38, 0x36, 0xa9,
+	0x0a, 0x7a, 0x0f, 0xfd, 0x6f, 0x12, 0xa0, 0x30, 0x57, 0xa1, 0xed, 0x0f, 0x20, 0xed, 0xdd, 0x8e,
+	0x08, 0x63, 0xb7, 0x7a, 0xb8, 0xc3, 0x03, 0x12, 0xa7, 0x2b, 0x37, 0x6f, 0x47, 0x04, 0x33, 0xd2,
+	0x49, 0x9c, 0x53, 0x33, 0xe2, 0x3c, 0xad, 0xa9, 0x1c, 0xd3, 0x54, 0x3f, 0x86, 0x34, 0x65, 0x88,
+	0x36, 0xa1, 0xd0, 0xfc, 0xf2, 0xa2, 0x36, 0x35, 0x28, 0xc9, 0xc3, 0xd2, 0x31, 0xae, 0x55, 0x9a,
+	0xb5, 0x6a, 0x41, 0xa2, 0x8b, 0xcb, 0x8b, 0x2a, 0x5b, 0xa4, 0xe8, 0xa2, 0x5a, 0x3b, 0xab, 0xd1,
+	0x85, 0x4c, 0xf3, 0x03, 0xd5, 0x07, 0x23, 0xdb, 0x89, 0x3e, 0x5a, 0x17, 0x7d, 0xac, 0xec, 0x47,
+	0x27, 0x3a, 0x53, 0x63, 0x91, 0xd0, 0x68, 0x87, 0x36, 0x81, 0xa6, 0x73, 0xdb, 0x72, 0xc6, 0xdc,
+	0x96, 0x1c, 0xce, 0x9a, 0xce, 0x2d, 0x1e, 0x0f, 0x69, 0x2f, 0x36, 0x1e, 0xb9, 0xc4, 0xe1, 0x13,
+	0xbd, 0x1c, 0x16, 0x2b, 0xfd, 0xaf, 0x29, 0x58, 0x8f, 0x68, 0xe6, 0x8e, 0xfb, 0x6c, 0x20, 0x60,
+	0x0d, 0x4d, 0x72, 0xc3, 0x34, 0xcb, 0x60, 0xbe, 0x40, 0x7b, 0x09, 0x6a, 0x44, 0xa4, 0x7f, 0x0a,
+	0x59, 0xa3, 0x4d, 0x07, 0x95, 0x4c, 0xf8, 0xea, 0xe1, 0x2e, 0xb7, 0x28, 0xc6, 0xbf, 0x5c, 0x61,
+	0x54, 0x58, 0x50, 0x87, 0x5b, 0xd7, 0x74, 0xa4, 0x75, 0xdd, 0x01, 0x20, 0x8e, 0x63, 0x3b, 0xad,
+	0xb6, 0x6d, 0x12, 0xd1, 0x72, 0x2a, 0x0c, 0x72, 0x6c, 0x9b, 0x84, 0x86, 0x8f, 0xa3, 0x1d, 0x62,
+	0xb8, 0xf6, 0x90, 0x3d, 0x88, 0x15, 0x9c, 0x67, 0x30, 0xcc, 0x40, 0xb4, 0x61, 0xe4, 0x24, 0x03,
+	0xe2, 0xba, 0x46, 0x97, 0x88, 0x56, 0x94, 0xef, 0xfb, 0x82, 0xc3, 0xf4, 0x13, 0xc8, 0x72, 0x8d,
+	0xe8, 0x40, 0xac, 0x72, 0xdc, 0xac, 0xbf, 0x38, 0x5f, 0x38, 0xce, 0x00, 0xd9, 0x93, 0x4a, 0xfd,
+	0x8c, 0x85, 0xf9, 0xb7, 0x12, 0x6c, 0x44, 0x8d, 0xf5, 0x33, 0x77, 0xc9, 0x61, 0x86, 0xfb, 0xb7,
+	0xc5, 0xf6, 0x0c, 0xc7, 0x60, 0x9f, 0x8e, 0x75, 0xda, 0xbc, 0xeb, 0xe5, 0x37, 0x1a, 0xf6, 0x97,
+	0x14, 0x23, 0x66, 0x9f, 0xcc, 0xcb, 0x19, 0xec, 0x2f, 0x69, 0x8c, 0x3b, 0x86, 0xd5, 0x27, 0xdc,
+	0x8b, 0x19, 0x2c, 0x56, 0xfa, 0x3f, 0x24, 0x40, 0xb5, 0x9b, 0x58, 0xf6, 0x3d, 0xf8, 0x74, 0x13,
+	0xe3, 0x8a, 0x54, 0x30, 0xae, 0x88, 0x0d, 0x51, 0xe4, 0xff, 0x78, 0x88, 0x92, 0x7e, 0xdd, 0x21,
+	0x4a, 0xd0, 0xd9, 0x67, 0xe6, 0x8d, 0x2a, 0x3e, 0x81, 0x8d, 0xda, 0x4d, 0x3c, 0x14, 0x73, 0x2a,
+	0xff, 0xe1, 0xdf, 0x01, 0xf2, 0x74, 0xd9, 0x20, 0xce, 0x2b, 0xab, 0x4d, 0xd0, 0x73, 0x80, 0x60,
+	0xc6, 0x88, 0x44, 0xdc, 0x62, 0x43, 0x4e, 0x4d, 0x8d, 0x23, 0x84, 0xc0, 0x67, 0x90, 0xf3, 0x27,
+	0x80, 0xe8, 0x3d, 0x4e, 0x35, 0x35, 0x7e, 0xd4, 0xb6, 0xa6, 0xc1, 0x62, 0x6b, 0x15, 0xf2, 0xa1,
+	0x31, 0x1b, 0x12, 0x32, 0xe2, 0xb3, 0x41, 0xad, 0x98, 0x80, 0xe1, 0x3c, 0x9e, 0x4a, 0xe8, 0x87,
+	0xa0, 0x4c, 0xc6, 0x30, 0x68, 0x2b, 0x79, 0xc8, 0xa4, 0x6d, 0xc7, 0xe0, 0x42, 0x87, 0xe7, 0x00,
+	0xc1, 0x28, 0xc3, 0xb7, 0x3f, 0x36, 0x76, 0xd1, 0xd4, 0x38, 0x22, 0x60, 0x10, 0xcc, 0x1a, 0x7c,
+	0x06, 0xb1, 0x39, 0x87, 0xa6, 0xc6, 0x11, 0x82, 0xc1, 0xcf, 0xf8, 0xb4, 0x2f, 0xfc, 0xe0, 0x47,
+	0x3b, 0x81, 0xba, 0x09, 0x43, 0x09, 0x6d, 0x77, 0x16, 0x5a, 0xb0, 0x3c, 0x82, 0x7c, 0xa8, 0x3b,
+	0xf0, 0x1d, 0x1b, 0x7f, 0xf0, 0x6b, 0xc5, 0x04, 0x4c, 0xc0, 0x23, 0xf4, 0x32, 0xf2, 0x79, 0xc4,
+	0xdf, 0x65, 0x5a, 0x31, 0x01, 0x23, 0x78, 0x9c, 0xc2, 0x4a, 0xe4, 0xe5, 0x82, 0x34, 0xe1, 0xc6,
+	0x84, 0xb7, 0x93, 0xf6, 0x38, 0x11, 0x27, 0x38, 0xd5, 0x60, 0x39, 0xfc, 0x34, 0x40, 0x7e, 0x46,
+	0xc4, 0x5f, 0x37, 0x9a, 0x96, 0x84, 0x0a, 0x8c, 0x0a, 0xb5, 0xef, 0xbe, 0x51, 0xf1, 0xc7, 0x83,
+	0x56, 0x4c, 0xc0, 0x08, 0x1e, 0xcd, 0x60, 0xec, 0x37, 0xe9, 0x38, 0xd0, 0x6e, 0x34, 0xbf, 0xa6,
+	0x7b, 0x24, 0x6d, 0x6f, 0x26, 0x5e, 0x70, 0x3d, 0x87, 0xb5, 0xa9, 0xb6, 0x15, 0x89, 0x81, 0x69,
+	0x72, 0xbf, 0xac, 0xed, 0xcc, 0xc0, 0x0a, 0x7e, 0x5f, 0x4d, 0x4d, 0x5d, 0x04, 0xcf, 0x52, 0x42,
+	0xc0, 0xa3, 0x7c, 0x9f, 0x3c, 0x40, 0x11, 0x78, 0x20, 0xd6, 0x73, 0xf9, 0x1e, 0x98, 0xd5, 0x25,
+	0x6a, 0x7b, 0x33, 0xf1, 0x82, 0x6b, 0x05, 0x20, 0x68, 0x76, 0xfc, 0x83, 0x14, 0x6b, 0xbe, 0x34,
+	0x35, 0x8e, 0x98, 0x94, 0x82, 0x2a, 0xe4, 0x43, 0x57, 0x8e, 0x1f, 0xde, 0x78, 0x63, 0xa2, 0x15,
+	0x13, 0x30, 0x9c, 0xcb, 0x3e, 0xe3, 0x52, 0xbb, 0x89, 0x71, 0xa9, 0xdd, 0xcc, 0xe2, 0x92, 0x50,
+	0x86, 0x9f, 0x4a, 0x47, 0xb9, 0xaf, 0xb2, 0xfc, 0x8f, 0xa9, 0x57, 0x59, 0x56, 0xf7, 0x3f, 0xfa,
+	0xf7, 0x00, 0xb6, 0x09, 0x58, 0x29, 0x62, 0x1d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BlogServiceClient is the client API for BlogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlogServiceClient interface {
+	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
+	ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error)
+	// streams the blogs matching the query, most relevant first
+	SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (BlogService_SearchBlogsClient, error)
+	// pages through the blogs matching the filters, sorted by creation time
+	ListBlogs(ctx context.Context, in *ListBlogsRequest, opts ...grpc.CallOption) (*ListBlogsResponse, error)
+	// records the new blog as a revision
+	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	// moves the blog to the trash, it is deleted with its revisions after the
+	// retention of the server
+	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
+	ListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest, opts ...grpc.CallOption) (*ListDeletedBlogsResponse, error)
+	// takes the blog out of the trash
+	RestoreBlog(ctx context.Context, in *RestoreBlogRequest, opts ...grpc.CallOption) (*RestoreBlogResponse, error)
+	PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*PublishBlogResponse, error)
+	UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UnpublishBlogResponse, error)
+	ScheduleBlog(ctx context.Context, in *ScheduleBlogRequest, opts ...grpc.CallOption) (*ScheduleBlogResponse, error)
+	ArchiveBlog(ctx context.Context, in *ArchiveBlogRequest, opts ...grpc.CallOption) (*ArchiveBlogResponse, error)
+	ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (*ListBlogRevisionsResponse, error)
+	GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*GetBlogRevisionResponse, error)
+	// makes an old revision current by recording a copy of it
+	RestoreBlogRevision(ctx context.Context, in *RestoreBlogRevisionRequest, opts ...grpc.CallOption) (*RestoreBlogRevisionResponse, error)
+	DiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest, opts ...grpc.CallOption) (*DiffBlogRevisionsResponse, error)
+	// streams the changes of the blogs in the order they are made, until the
+	// client cancels, returns OUT_OF_RANGE when the changes after the resume
+	// token are no longer kept
+	WatchBlogs(ctx context.Context, in *WatchBlogsRequest, opts ...grpc.CallOption) (BlogService_WatchBlogsClient, error)
+	// creates or updates the blogs sent, a blog that fails does not fail the
+	// others
+	ImportBlogs(ctx context.Context, opts ...grpc.CallOption) (BlogService_ImportBlogsClient, error)
+	// streams the blogs matching the filters, oldest first, the blogs in the
+	// trash are left out
+	ExportBlogs(ctx context.Context, in *ExportBlogsRequest, opts ...grpc.CallOption) (BlogService_ExportBlogsClient, error)
+}
+
+type blogServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlogServiceClient(cc *grpc.ClientConn) BlogServiceClient {
+	return &blogServiceClient{cc}
+}
+
+func (c *blogServiceClient) CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
+	out := new(CreateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/CreateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ReadBlog(ctx context.Context, in *ReadBlogRequest, opts ...grpc.CallOption) (*ReadBlogResponse, error) {
+	out := new(ReadBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ReadBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (BlogService_SearchBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[0], "/blog.BlogService/SearchBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceSearchBlogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_SearchBlogsClient interface {
+	Recv() (*SearchBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceSearchBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceSearchBlogsClient) Recv() (*SearchBlogsResponse, error) {
+	m := new(SearchBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) ListBlogs(ctx context.Context, in *ListBlogsRequest, opts ...grpc.CallOption) (*ListBlogsResponse, error) {
+	out := new(ListBlogsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ListBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UpdateBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
+	out := new(DeleteBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DeleteBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest, opts ...grpc.CallOption) (*ListDeletedBlogsResponse, error) {
+	out := new(ListDeletedBlogsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ListDeletedBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RestoreBlog(ctx context.Context, in *RestoreBlogRequest, opts ...grpc.CallOption) (*RestoreBlogResponse, error) {
+	out := new(RestoreBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RestoreBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*PublishBlogResponse, error) {
+	out := new(PublishBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/PublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UnpublishBlogResponse, error) {
+	out := new(UnpublishBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/UnpublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ScheduleBlog(ctx context.Context, in *ScheduleBlogRequest, opts ...grpc.CallOption) (*ScheduleBlogResponse, error) {
+	out := new(ScheduleBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ScheduleBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ArchiveBlog(ctx context.Context, in *ArchiveBlogRequest, opts ...grpc.CallOption) (*ArchiveBlogResponse, error) {
+	out := new(ArchiveBlogResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ArchiveBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (*ListBlogRevisionsResponse, error) {
+	out := new(ListBlogRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/ListBlogRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*GetBlogRevisionResponse, error) {
+	out := new(GetBlogRevisionResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/GetBlogRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RestoreBlogRevision(ctx context.Context, in *RestoreBlogRevisionRequest, opts ...grpc.CallOption) (*RestoreBlogRevisionResponse, error) {
+	out := new(RestoreBlogRevisionResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/RestoreBlogRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest, opts ...grpc.CallOption) (*DiffBlogRevisionsResponse, error) {
+	out := new(DiffBlogRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/blog.BlogService/DiffBlogRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) WatchBlogs(ctx context.Context, in *WatchBlogsRequest, opts ...grpc.CallOption) (BlogService_WatchBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[1], "/blog.BlogService/WatchBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceWatchBlogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_WatchBlogsClient interface {
+	Recv() (*WatchBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceWatchBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceWatchBlogsClient) Recv() (*WatchBlogsResponse, error) {
+	m := new(WatchBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) ImportBlogs(ctx context.Context, opts ...grpc.CallOption) (BlogService_ImportBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[2], "/blog.BlogService/ImportBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceImportBlogsClient{stream}
+	return x, nil
+}
+
+type BlogService_ImportBlogsClient interface {
+	Send(*ImportBlogsRequest) error
+	CloseAndRecv() (*ImportBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceImportBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceImportBlogsClient) Send(m *ImportBlogsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blogServiceImportBlogsClient) CloseAndRecv() (*ImportBlogsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blogServiceClient) ExportBlogs(ctx context.Context, in *ExportBlogsRequest, opts ...grpc.CallOption) (BlogService_ExportBlogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlogService_serviceDesc.Streams[3], "/blog.BlogService/ExportBlogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blogServiceExportBlogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlogService_ExportBlogsClient interface {
+	Recv() (*ExportBlogsResponse, error)
+	grpc.ClientStream
+}
+
+type blogServiceExportBlogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blogServiceExportBlogsClient) Recv() (*ExportBlogsResponse, error) {
+	m := new(ExportBlogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlogServiceServer is the server API for BlogService service.
+type BlogServiceServer interface {
+	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
+	ReadBlog(context.Context, *ReadBlogRequest) (*ReadBlogResponse, error)
+	// streams the blogs matching the query, most relevant first
+	SearchBlogs(*SearchBlogsRequest, BlogService_SearchBlogsServer) error
+	// pages through the blogs matching the filters, sorted by creation time
+	ListBlogs(context.Context, *ListBlogsRequest) (*ListBlogsResponse, error)
+	// records the new blog as a revision
+	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
+	// moves the blog to the trash, it is deleted with its revisions after the
+	// retention of the server
+	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
+	ListDeletedBlogs(context.Context, *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error)
+	// takes the blog out of the trash
+	RestoreBlog(context.Context, *RestoreBlogRequest) (*RestoreBlogResponse, error)
+	PublishBlog(context.Context, *PublishBlogRequest) (*PublishBlogResponse, error)
+	UnpublishBlog(context.Context, *UnpublishBlogRequest) (*UnpublishBlogResponse, error)
+	ScheduleBlog(context.Context, *ScheduleBlogRequest) (*ScheduleBlogResponse, error)
+	ArchiveBlog(context.Context, *ArchiveBlogRequest) (*ArchiveBlogResponse, error)
+	ListBlogRevisions(context.Context, *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error)
+	GetBlogRevision(context.Context, *GetBlogRevisionRequest) (*GetBlogRevisionResponse, error)
+	// makes an old revision current by recording a copy of it
+	RestoreBlogRevision(context.Context, *RestoreBlogRevisionRequest) (*RestoreBlogRevisionResponse, error)
+	DiffBlogRevisions(context.Context, *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error)
+	// streams the changes of the blogs in the order they are made, until the
+	// client cancels, returns OUT_OF_RANGE when the changes after the resume
+	// token are no longer kept
+	WatchBlogs(*WatchBlogsRequest, BlogService_WatchBlogsServer) error
+	// creates or updates the blogs sent, a blog that fails does not fail the
+	// others
+	ImportBlogs(BlogService_ImportBlogsServer) error
+	// streams the blogs matching the filters, oldest first, the blogs in the
+	// trash are left out
+	ExportBlogs(*ExportBlogsRequest, BlogService_ExportBlogsServer) error
+}
+
+func RegisterBlogServiceServer(s *grpc.Server, srv BlogServiceServer) {
+	s.RegisterService(&_BlogService_serviceDesc, srv)
+}
+
+func _BlogService_CreateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).CreateBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/CreateBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).CreateBlog(ctx, req.(*CreateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ReadBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ReadBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ReadBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ReadBlog(ctx, req.(*ReadBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_SearchBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchBlogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).SearchBlogs(m, &blogServiceSearchBlogsServer{stream})
+}
+
+type BlogService_SearchBlogsServer interface {
+	Send(*SearchBlogsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceSearchBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceSearchBlogsServer) Send(m *SearchBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_ListBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ListBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogs(ctx, req.(*ListBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UpdateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UpdateBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UpdateBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UpdateBlog(ctx, req.(*UpdateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DeleteBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DeleteBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DeleteBlog(ctx, req.(*DeleteBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListDeletedBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListDeletedBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ListDeletedBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListDeletedBlogs(ctx, req.(*ListDeletedBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RestoreBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RestoreBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RestoreBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RestoreBlog(ctx, req.(*RestoreBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_PublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PublishBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/PublishBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PublishBlog(ctx, req.(*PublishBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UnpublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/UnpublishBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, req.(*UnpublishBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ScheduleBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ScheduleBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ScheduleBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ScheduleBlog(ctx, req.(*ScheduleBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ArchiveBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ArchiveBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ArchiveBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ArchiveBlog(ctx, req.(*ArchiveBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlogRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/ListBlogRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogRevisions(ctx, req.(*ListBlogRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetBlogRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/GetBlogRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, req.(*GetBlogRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RestoreBlogRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBlogRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RestoreBlogRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/RestoreBlogRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RestoreBlogRevision(ctx, req.(*RestoreBlogRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DiffBlogRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffBlogRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DiffBlogRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blog.BlogService/DiffBlogRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DiffBlogRevisions(ctx, req.(*DiffBlogRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_WatchBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBlogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).WatchBlogs(m, &blogServiceWatchBlogsServer{stream})
+}
+
+type BlogService_WatchBlogsServer interface {
+	Send(*WatchBlogsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceWatchBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceWatchBlogsServer) Send(m *WatchBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BlogService_ImportBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlogServiceServer).ImportBlogs(&blogServiceImportBlogsServer{stream})
+}
+
+type BlogService_ImportBlogsServer interface {
+	SendAndClose(*ImportBlogsResponse) error
+	Recv() (*ImportBlogsRequest, error)
+	grpc.ServerStream
+}
+
+type blogServiceImportBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceImportBlogsServer) SendAndClose(m *ImportBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blogServiceImportBlogsServer) Recv() (*ImportBlogsRequest, error) {
+	m := new(ImportBlogsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _BlogService_ExportBlogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBlogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlogServiceServer).ExportBlogs(m, &blogServiceExportBlogsServer{stream})
+}
+
+type BlogService_ExportBlogsServer interface {
+	Send(*ExportBlogsResponse) error
+	grpc.ServerStream
+}
+
+type blogServiceExportBlogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blogServiceExportBlogsServer) Send(m *ExportBlogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _BlogService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "blog.BlogService",
+	HandlerType: (*BlogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBlog",
+			Handler:    _BlogService_CreateBlog_Handler,
+		},
 		{
 			MethodName: "ReadBlog",
 			Handler:    _BlogService_ReadBlog_Handler,
 		},
+		{
+			MethodName: "ListBlogs",
+			Handler:    _BlogService_ListBlogs_Handler,
+		},
+		{
+			MethodName: "UpdateBlog",
+			Handler:    _BlogService_UpdateBlog_Handler,
+		},
+		{
+			MethodName: "DeleteBlog",
+			Handler:    _BlogService_DeleteBlog_Handler,
+		},
+		{
+			MethodName: "ListDeletedBlogs",
+			Handler:    _BlogService_ListDeletedBlogs_Handler,
+		},
+		{
+			MethodName: "RestoreBlog",
+			Handler:    _BlogService_RestoreBlog_Handler,
+		},
+		{
+			MethodName: "PublishBlog",
+			Handler:    _BlogService_PublishBlog_Handler,
+		},
+		{
+			MethodName: "UnpublishBlog",
+			Handler:    _BlogService_UnpublishBlog_Handler,
+		},
+		{
+			MethodName: "ScheduleBlog",
+			Handler:    _BlogService_ScheduleBlog_Handler,
+		},
+		{
+			MethodName: "ArchiveBlog",
+			Handler:    _BlogService_ArchiveBlog_Handler,
+		},
+		{
+			MethodName: "ListBlogRevisions",
+			Handler:    _BlogService_ListBlogRevisions_Handler,
+		},
+		{
+			MethodName: "GetBlogRevision",
+			Handler:    _BlogService_GetBlogRevision_Handler,
+		},
+		{
+			MethodName: "RestoreBlogRevision",
+			Handler:    _BlogService_RestoreBlogRevision_Handler,
+		},
+		{
+			MethodName: "DiffBlogRevisions",
+			Handler:    _BlogService_DiffBlogRevisions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchBlogs",
+			Handler:       _BlogService_SearchBlogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBlogs",
+			Handler:       _BlogService_WatchBlogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportBlogs",
+			Handler:       _BlogService_ImportBlogs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportBlogs",
+			Handler:       _BlogService_ExportBlogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "blog/blogpb/blog.proto",
 }
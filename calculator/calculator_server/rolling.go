@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxWindowSize = 10000
+const maxWindowDuration = time.Hour
+const minWindowSlide = 10 * time.Millisecond
+
+// numbers a time based window can hold, a client sending faster than that
+// over the window duration gets RESOURCE_EXHAUSTED
+const maxWindowSamples = 100000
+
+type sample struct {
+	at     time.Time
+	number float64
+}
+
+// rollingWindow holds the numbers of the current window and aggregates them
+type rollingWindow struct {
+	aggregate calculatorpb.Aggregate
+	kind      calculatorpb.Window_Kind
+	size      int
+	duration  time.Duration
+	slide     time.Duration
+	samples   []sample
+	sequence  int64
+}
+
+func newRollingWindow(req *calculatorpb.RollingAggregateRequest) (*rollingWindow, error) {
+	aggregate := req.GetAggregate()
+	if _, ok := calculatorpb.Aggregate_name[int32(aggregate)]; !ok || aggregate == calculatorpb.Aggregate_AGGREGATE_UNSPECIFIED {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Receive an invalid aggregate: %v", aggregate),
+		)
+	}
+
+	window := req.GetWindow()
+	w := &rollingWindow{
+		aggregate: aggregate,
+		kind:      window.GetKind(),
+		size:      int(window.GetSize()),
+		duration:  time.Duration(window.GetDurationMs()) * time.Millisecond,
+		slide:     time.Duration(window.GetSlideMs()) * time.Millisecond,
+	}
+
+	switch {
+	case window == nil:
+		return nil, status.Error(codes.InvalidArgument, "The first message must define the window")
+	case w.size < 0 || w.size > maxWindowSize:
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Window size must be between 1 and %v: %v", maxWindowSize, w.size),
+		)
+	case w.size > 0 && w.duration > 0:
+		return nil, status.Error(codes.InvalidArgument, "Window must be either count based or time based")
+	case w.size == 0 && (w.duration < minWindowSlide || w.duration > maxWindowDuration):
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Window duration must be between %v and %v: %v", minWindowSlide, maxWindowDuration, w.duration),
+		)
+	case w.kind != calculatorpb.Window_TUMBLING && w.kind != calculatorpb.Window_SLIDING:
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Receive an invalid window kind: %v", w.kind),
+		)
+	case w.slide != 0 && (!w.timeBased() || w.kind != calculatorpb.Window_SLIDING):
+		return nil, status.Error(codes.InvalidArgument, "Window slide is only for time based sliding windows")
+	case w.slide < 0:
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Window slide cannot be negative: %v", w.slide),
+		)
+	}
+
+	if w.timeBased() && w.kind == calculatorpb.Window_SLIDING {
+		if w.slide == 0 {
+			w.slide = w.duration / 10
+		}
+		if w.slide < minWindowSlide {
+			w.slide = minWindowSlide
+		}
+		if w.slide > w.duration {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("Window slide cannot be longer than its duration: %v", w.slide),
+			)
+		}
+	}
+
+	return w, nil
+}
+
+func (w *rollingWindow) timeBased() bool {
+	return w.size == 0
+}
+
+// interval is how often a time based window has to be emitted
+func (w *rollingWindow) interval() time.Duration {
+	if w.kind == calculatorpb.Window_SLIDING {
+		return w.slide
+	}
+	return w.duration
+}
+
+// add records a number and returns the windows that became ready
+func (w *rollingWindow) add(at time.Time, number float64) ([]*calculatorpb.RollingAggregateResponse, error) {
+	if w.timeBased() {
+		if w.kind == calculatorpb.Window_SLIDING {
+			// the expired numbers don't count against the limit
+			w.evictBefore(at.Add(-w.duration))
+		}
+		if len(w.samples) >= maxWindowSamples {
+			return nil, status.Error(
+				codes.ResourceExhausted,
+				fmt.Sprintf("A window can hold at most %v numbers, send them slower or use a shorter window", maxWindowSamples),
+			)
+		}
+		w.samples = append(w.samples, sample{at: at, number: number})
+		return nil, nil
+	}
+
+	w.samples = append(w.samples, sample{at: at, number: number})
+	if w.kind == calculatorpb.Window_SLIDING {
+		if len(w.samples) > w.size {
+			w.samples = w.samples[len(w.samples)-w.size:]
+		}
+		return w.emit(), nil
+	}
+
+	if len(w.samples) == w.size {
+		res := w.emit()
+		w.samples = nil
+		return res, nil
+	}
+	return nil, nil
+}
+
+// tick closes a time based window
+func (w *rollingWindow) tick(now time.Time) []*calculatorpb.RollingAggregateResponse {
+	if w.kind == calculatorpb.Window_SLIDING {
+		w.evictBefore(now.Add(-w.duration))
+		return w.emit()
+	}
+	res := w.emit()
+	w.samples = nil
+	return res
+}
+
+// flush emits what is left of a tumbling window once the client is done
+func (w *rollingWindow) flush() []*calculatorpb.RollingAggregateResponse {
+	if w.kind == calculatorpb.Window_SLIDING {
+		return nil
+	}
+	return w.emit()
+}
+
+func (w *rollingWindow) evictBefore(t time.Time) {
+	i := 0
+	for i < len(w.samples) && w.samples[i].at.Before(t) {
+		i++
+	}
+	w.samples = w.samples[i:]
+}
+
+func (w *rollingWindow) emit() []*calculatorpb.RollingAggregateResponse {
+	if len(w.samples) == 0 {
+		return nil
+	}
+	w.sequence++
+	return []*calculatorpb.RollingAggregateResponse{
+		&calculatorpb.RollingAggregateResponse{
+			Window: w.sequence,
+			Value:  w.value(),
+			Count:  int64(len(w.samples)),
+		},
+	}
+}
+
+func (w *rollingWindow) value() float64 {
+	// the first number seeds the aggregate so negative numbers are handled
+	result := w.samples[0].number
+	for _, s := range w.samples[1:] {
+		switch w.aggregate {
+		case calculatorpb.Aggregate_MAX:
+			if s.number > result {
+				result = s.number
+			}
+		case calculatorpb.Aggregate_MIN:
+			if s.number < result {
+				result = s.number
+			}
+		case calculatorpb.Aggregate_SUM, calculatorpb.Aggregate_MEAN:
+			result += s.number
+		}
+	}
+	if w.aggregate == calculatorpb.Aggregate_MEAN {
+		result /= float64(len(w.samples))
+	}
+	return result
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/calculator/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// start of the tests, the windows are given the time of every number
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func countWindow(aggregate calculatorpb.Aggregate, kind calculatorpb.Window_Kind, size int32) *calculatorpb.RollingAggregateRequest {
+	return &calculatorpb.RollingAggregateRequest{
+		Aggregate: aggregate,
+		Window:    &calculatorpb.Window{Kind: kind, Size: size},
+	}
+}
+
+func timeWindow(aggregate calculatorpb.Aggregate, kind calculatorpb.Window_Kind, duration, slide time.Duration) *calculatorpb.RollingAggregateRequest {
+	return &calculatorpb.RollingAggregateRequest{
+		Aggregate: aggregate,
+		Window: &calculatorpb.Window{
+			Kind:       kind,
+			DurationMs: int64(duration / time.Millisecond),
+			SlideMs:    int64(slide / time.Millisecond),
+		},
+	}
+}
+
+// emitted is a window as the test expects it
+type emitted struct {
+	window int64
+	value  float64
+	count  int64
+}
+
+func toEmitted(responses []*calculatorpb.RollingAggregateResponse) []emitted {
+	var got []emitted
+	for _, res := range responses {
+		got = append(got, emitted{res.GetWindow(), res.GetValue(), res.GetCount()})
+	}
+	return got
+}
+
+func TestNewRollingWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *calculatorpb.RollingAggregateRequest
+		wantCode  codes.Code
+		wantSlide time.Duration
+	}{
+		{"count", countWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, 3), codes.OK, 0},
+		{"no aggregate", countWindow(calculatorpb.Aggregate_AGGREGATE_UNSPECIFIED, calculatorpb.Window_SLIDING, 3), codes.InvalidArgument, 0},
+		{"no window", &calculatorpb.RollingAggregateRequest{Aggregate: calculatorpb.Aggregate_SUM}, codes.InvalidArgument, 0},
+		{"unknown kind", countWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_Kind(7), 3), codes.InvalidArgument, 0},
+		{"size too large", countWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_TUMBLING, maxWindowSize+1), codes.InvalidArgument, 0},
+		{
+			"size and duration",
+			&calculatorpb.RollingAggregateRequest{
+				Aggregate: calculatorpb.Aggregate_SUM,
+				Window:    &calculatorpb.Window{Size: 3, DurationMs: 1000},
+			},
+			codes.InvalidArgument, 0,
+		},
+		{
+			"slide of a count window",
+			&calculatorpb.RollingAggregateRequest{
+				Aggregate: calculatorpb.Aggregate_SUM,
+				Window:    &calculatorpb.Window{Kind: calculatorpb.Window_SLIDING, Size: 3, SlideMs: 100},
+			},
+			codes.InvalidArgument, 0,
+		},
+		{"slide of a tumbling window", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_TUMBLING, time.Second, 100*time.Millisecond), codes.InvalidArgument, 0},
+		{"duration under the minimum", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Millisecond, 0), codes.InvalidArgument, 0},
+		{"tumbling duration under the minimum", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_TUMBLING, 5*time.Millisecond, 0), codes.InvalidArgument, 0},
+		{"duration too long", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, 2*time.Hour, 0), codes.InvalidArgument, 0},
+		{"negative slide", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Second, -time.Second), codes.InvalidArgument, 0},
+		{"slide longer than the duration", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Second, 2*time.Second), codes.InvalidArgument, 0},
+		{"default slide", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Second, 0), codes.OK, 100 * time.Millisecond},
+		{"default slide raised to the minimum", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, 50*time.Millisecond, 0), codes.OK, minWindowSlide},
+		{"slide raised to the minimum", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Second, time.Millisecond), codes.OK, minWindowSlide},
+		{"shortest window", timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, minWindowSlide, 0), codes.OK, minWindowSlide},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := newRollingWindow(tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v, want %v: %v", code, tt.wantCode, err)
+			}
+			if err == nil && w.slide != tt.wantSlide {
+				t.Errorf("slide = %v, want %v", w.slide, tt.wantSlide)
+			}
+		})
+	}
+}
+
+func TestCountWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *calculatorpb.RollingAggregateRequest
+		numbers []float64
+		want    []emitted
+		// emitted once the client is done
+		flushed []emitted
+	}{
+		{
+			name:    "tumbling sum",
+			req:     countWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_TUMBLING, 2),
+			numbers: []float64{1, 2, 3, 4, 5},
+			want:    []emitted{{1, 3, 2}, {2, 7, 2}},
+			flushed: []emitted{{3, 5, 1}},
+		},
+		{
+			name:    "sliding mean",
+			req:     countWindow(calculatorpb.Aggregate_MEAN, calculatorpb.Window_SLIDING, 3),
+			numbers: []float64{3, 6, 9, 12},
+			want:    []emitted{{1, 3, 1}, {2, 4.5, 2}, {3, 6, 3}, {4, 9, 3}},
+		},
+		{
+			// a max starting from 0 would never see the numbers
+			name:    "max of negative numbers",
+			req:     countWindow(calculatorpb.Aggregate_MAX, calculatorpb.Window_SLIDING, 2),
+			numbers: []float64{-5, -3, -8, -9},
+			want:    []emitted{{1, -5, 1}, {2, -3, 2}, {3, -3, 2}, {4, -8, 2}},
+		},
+		{
+			// a min starting from 0 would always be 0
+			name:    "min of positive numbers",
+			req:     countWindow(calculatorpb.Aggregate_MIN, calculatorpb.Window_TUMBLING, 3),
+			numbers: []float64{5, 3, 8, 9, 7, 6},
+			want:    []emitted{{1, 3, 3}, {2, 6, 3}},
+		},
+		{
+			name:    "sum of negative numbers",
+			req:     countWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_TUMBLING, 2),
+			numbers: []float64{-1, -2, -3},
+			want:    []emitted{{1, -3, 2}},
+			flushed: []emitted{{2, -3, 1}},
+		},
+		{
+			name:    "first value of every window",
+			req:     countWindow(calculatorpb.Aggregate_MIN, calculatorpb.Window_TUMBLING, 1),
+			numbers: []float64{-1, 4, 0},
+			want:    []emitted{{1, -1, 1}, {2, 4, 1}, {3, 0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := newRollingWindow(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var responses []*calculatorpb.RollingAggregateResponse
+			for i, number := range tt.numbers {
+				res, err := w.add(epoch.Add(time.Duration(i)*time.Second), number)
+				if err != nil {
+					t.Fatalf("add %v: %v", number, err)
+				}
+				responses = append(responses, res...)
+			}
+			if got := toEmitted(responses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("emitted %v, want %v", got, tt.want)
+			}
+			if got := toEmitted(w.flush()); !reflect.DeepEqual(got, tt.flushed) {
+				t.Errorf("flushed %v, want %v", got, tt.flushed)
+			}
+		})
+	}
+}
+
+// step is a number added, or a tick of the window when tick is set, at
+// the given time after epoch
+type step struct {
+	at     time.Duration
+	tick   bool
+	number float64
+}
+
+func TestTimeWindows(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   *calculatorpb.RollingAggregateRequest
+		steps []step
+		want  []emitted
+	}{
+		{
+			name: "tumbling",
+			req:  timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_TUMBLING, time.Second, 0),
+			steps: []step{
+				{at: 100 * time.Millisecond, number: 1},
+				{at: 500 * time.Millisecond, number: 2},
+				{at: time.Second, tick: true},
+				{at: 1500 * time.Millisecond, number: 4},
+				{at: 2 * time.Second, tick: true},
+				// nothing is emitted for an empty window
+				{at: 3 * time.Second, tick: true},
+				{at: 3500 * time.Millisecond, number: -4},
+				{at: 4 * time.Second, tick: true},
+			},
+			want: []emitted{{1, 3, 2}, {2, 4, 1}, {3, -4, 1}},
+		},
+		{
+			name: "sliding expiry",
+			req:  timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Second, 500*time.Millisecond),
+			steps: []step{
+				{at: 0, number: 1},
+				{at: 400 * time.Millisecond, number: 2},
+				{at: 500 * time.Millisecond, tick: true},
+				{at: 900 * time.Millisecond, number: 4},
+				// the number added at 0 expired
+				{at: 1100 * time.Millisecond, tick: true},
+				{at: 1500 * time.Millisecond, tick: true},
+				// all of them expired
+				{at: 3 * time.Second, tick: true},
+			},
+			want: []emitted{{1, 3, 2}, {2, 6, 2}, {3, 4, 1}},
+		},
+		{
+			name: "sliding max of negative numbers",
+			req:  timeWindow(calculatorpb.Aggregate_MAX, calculatorpb.Window_SLIDING, time.Second, 0),
+			steps: []step{
+				{at: 0, number: -7},
+				{at: 100 * time.Millisecond, tick: true},
+				{at: 200 * time.Millisecond, number: -2},
+				{at: 300 * time.Millisecond, tick: true},
+				{at: 1100 * time.Millisecond, tick: true},
+				{at: 1300 * time.Millisecond, tick: true},
+			},
+			want: []emitted{{1, -7, 1}, {2, -2, 2}, {3, -2, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := newRollingWindow(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var responses []*calculatorpb.RollingAggregateResponse
+			for _, s := range tt.steps {
+				if s.tick {
+					responses = append(responses, w.tick(epoch.Add(s.at))...)
+					continue
+				}
+				res, err := w.add(epoch.Add(s.at), s.number)
+				if err != nil {
+					t.Fatalf("add %v: %v", s.number, err)
+				}
+				responses = append(responses, res...)
+			}
+			if got := toEmitted(responses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("emitted %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeWindowLimit(t *testing.T) {
+	w, err := newRollingWindow(timeWindow(calculatorpb.Aggregate_SUM, calculatorpb.Window_SLIDING, time.Second, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// all of the numbers within the same second
+	for i := 0; i < maxWindowSamples; i++ {
+		if _, err := w.add(epoch.Add(time.Duration(i)), 1); err != nil {
+			t.Fatalf("number %v: %v", i, err)
+		}
+	}
+	_, err = w.add(epoch.Add(time.Millisecond), 1)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+
+	// once the numbers expire there is room again
+	if _, err := w.add(epoch.Add(2*time.Second), 1); err != nil {
+		t.Errorf("number after the window expired: %v", err)
+	}
+	if got := toEmitted(w.tick(epoch.Add(2 * time.Second))); !reflect.DeepEqual(got, []emitted{{1, 1, 1}}) {
+		t.Errorf("emitted %v, want the last number only", got)
+	}
+}
@@ -2,15 +2,22 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"math"
 	"net"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 
 	"github.com/FernandoDevBh/grpc-go-course/calculator/calculatorpb"
+	"github.com/FernandoDevBh/grpc-go-course/ratelimit"
+	"github.com/FernandoDevBh/grpc-go-course/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
@@ -20,8 +27,53 @@ const port = "50051"
 const address = "0.0.0.0"
 const network = "tcp"
 
+// domain of the ErrorInfo details attached to errors
+const errorDomain = "calculator.grpc-go-course"
+
+var (
+	rateLimitKey = flag.String("rate-limit-key", "peer", "how clients are told apart: peer, identity (TLS certificate) or api-key (x-api-key metadata)")
+	apiKeysFile  = flag.String("rate-limit-api-keys", "", "file with the API keys told apart by -rate-limit-key api-key, one per line, the other clients are told apart by IP address")
+	rateLimit    = flag.String("rate-limit", "50:100", "calls per second and burst of every client to each method, e.g. 50:100, unlimited when 0")
+	methodLimits = flag.String("method-rate-limits", "PrimeNumberDecomposition=2:5", "comma separated method=rate:burst limits replacing -rate-limit")
+	maxStreams   = flag.Int("max-streams", 10, "streams a client can have open at the same time, unlimited when 0")
+)
+
 type server struct{}
 
+// newLimiter builds the rate limiter configured by the flags
+func newLimiter() (*ratelimit.Limiter, error) {
+	var apiKeys []string
+	if *apiKeysFile != "" {
+		data, err := ioutil.ReadFile(*apiKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				apiKeys = append(apiKeys, line)
+			}
+		}
+	}
+	key, err := ratelimit.ParseKey(*rateLimitKey, apiKeys)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := ratelimit.ParseLimit(*rateLimit)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := ratelimit.ParseMethodLimits(*methodLimits)
+	if err != nil {
+		return nil, err
+	}
+	return ratelimit.New(ratelimit.Config{
+		Key:        key,
+		Default:    limit,
+		Methods:    methods,
+		MaxStreams: *maxStreams,
+	}), nil
+}
+
 func (*server) Sum(ctx context.Context, req *calculatorpb.SumRequest) (*calculatorpb.SumResponse, error) {
 	fmt.Printf("Calculator function was invoked with %v\n", req)
 	fn := req.GetSum().GetFirstNumber()
@@ -70,10 +122,58 @@ func (*server) ComputeAverage(stream calculatorpb.CalculatorService_ComputeAvera
 	}
 }
 
+func (*server) ComputeStatistics(stream calculatorpb.CalculatorService_ComputeStatisticsServer) error {
+	fmt.Printf("ComputeStatistics function was invoked with streaming request: %v\n", stream)
+	var st *statistics
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// We have finished reading the client stream
+			if st == nil {
+				return status.Error(
+					codes.InvalidArgument,
+					"Cannot compute statistics of an empty stream",
+				)
+			}
+			return stream.SendAndClose(st.response())
+		}
+		if err != nil {
+			log.Printf("Error while reading client stream: %v", err)
+			return err
+		}
+
+		if st == nil {
+			percentiles := req.GetPercentiles()
+			if len(percentiles) == 0 {
+				percentiles = defaultPercentiles
+			}
+			for _, p := range percentiles {
+				if p < 0 || p > 100 || math.IsNaN(p) {
+					return status.Error(
+						codes.InvalidArgument,
+						fmt.Sprintf("Percentile must be between 0 and 100: %v", p),
+					)
+				}
+			}
+			st = newStatistics(percentiles)
+		}
+
+		number := req.GetNumber()
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("Receive a number that is not finite: %v", number),
+			)
+		}
+		st.add(number)
+	}
+}
+
 func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServer) error {
 	fmt.Printf("FindMaximum function was invoked with streaming request: %v\n", stream)
 
 	var maximum int32
+	received := false
 
 	for {
 		req, err := stream.Recv()
@@ -87,7 +187,9 @@ func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServ
 		}
 
 		number := req.GetNumber()
-		if number > maximum {
+		// the first number is always a maximum, even a negative one
+		if !received || number > maximum {
+			received = true
 			maximum = number
 			err = stream.Send(&calculatorpb.FindMaximumResponse{
 				Maximum: maximum,
@@ -101,14 +203,120 @@ func (*server) FindMaximum(stream calculatorpb.CalculatorService_FindMaximumServ
 	}
 }
 
+func (*server) RollingAggregate(stream calculatorpb.CalculatorService_RollingAggregateServer) error {
+	fmt.Printf("RollingAggregate function was invoked with streaming request: %v\n", stream)
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error while reading client stream: %v", err)
+		return err
+	}
+
+	window, err := newRollingWindow(first)
+	if err != nil {
+		return err
+	}
+
+	// read the client in the background so time based windows can be emitted while waiting
+	reqs := make(chan *calculatorpb.RollingAggregateRequest)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case reqs <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	var ticks <-chan time.Time
+	if window.timeBased() {
+		ticker := time.NewTicker(window.interval())
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	send := func(responses []*calculatorpb.RollingAggregateResponse) error {
+		for _, res := range responses {
+			if err := stream.Send(res); err != nil {
+				log.Printf("Error while sending stream: %v", err)
+				return err
+			}
+		}
+		return nil
+	}
+
+	add := func(number float64) error {
+		responses, err := window.add(time.Now(), number)
+		if err != nil {
+			return err
+		}
+		return send(responses)
+	}
+
+	if err := add(first.GetNumber()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case req := <-reqs:
+			if err := add(req.GetNumber()); err != nil {
+				return err
+			}
+		case now := <-ticks:
+			if err := send(window.tick(now)); err != nil {
+				return err
+			}
+		case err := <-errs:
+			if err == io.EOF {
+				return send(window.flush())
+			}
+			log.Printf("Error while reading client stream: %v", err)
+			return err
+		}
+	}
+}
+
 func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootRequest) (*calculatorpb.SquareRootResponse, error) {
 	fmt.Printf("SquareRoot function was invoked with %v\n", req)
 	number := req.GetNumber()
 	if number < 0 {
-		return nil, status.Error(
+		st := status.New(
 			codes.InvalidArgument,
 			fmt.Sprintf("Receive a negative number: %v", number),
 		)
+		withDetails, err := st.WithDetails(
+			&errdetails.BadRequest{
+				FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					&errdetails.BadRequest_FieldViolation{
+						Field:       "number",
+						Description: "The number must be greater than or equal to zero",
+					},
+				},
+			},
+			&errdetails.ErrorInfo{
+				Reason: "NEGATIVE_NUMBER",
+				Domain: errorDomain,
+				Metadata: map[string]string{
+					"number": fmt.Sprint(number),
+				},
+			},
+		)
+		if err != nil {
+			// still an invalid argument, only without the details
+			return nil, st.Err()
+		}
+		return nil, withDetails.Err()
 	}
 
 	return &calculatorpb.SquareRootResponse{
@@ -117,12 +325,22 @@ func (*server) SquareRoot(ctx context.Context, req *calculatorpb.SquareRootReque
 }
 
 func main() {
+	flag.Parse()
+	limiter, err := newLimiter()
+	if err != nil {
+		log.Fatalf("Invalid rate limits: %v", err)
+	}
+
 	lis, err := net.Listen(network, fmt.Sprintf("%v:%v", address, port))
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	// clients over their limits are turned away before anything else
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(limiter.UnaryServerInterceptor(), validation.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(limiter.StreamServerInterceptor(), validation.StreamServerInterceptor()),
+	)
 
 	calculatorpb.RegisterCalculatorServiceServer(s, &server{})
 
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/FernandoDevBh/grpc-go-course/calculator/calculatorpb"
+)
+
+// percentiles reported when the client doesn't ask for any
+var defaultPercentiles = []float64{25, 75, 90, 95, 99}
+
+// statistics accumulates a stream of numbers in constant memory.
+// Mean and variance use Welford's algorithm, the sum uses Neumaier
+// compensated summation and the median / percentiles use P² estimators.
+type statistics struct {
+	count        int64
+	sum          float64
+	compensation float64
+	mean         float64
+	m2           float64
+	min          float64
+	max          float64
+	median       *quantile
+	percentiles  []*quantile
+}
+
+func newStatistics(percentiles []float64) *statistics {
+	st := &statistics{
+		median: newQuantile(50),
+	}
+	for _, p := range percentiles {
+		st.percentiles = append(st.percentiles, newQuantile(p))
+	}
+	return st
+}
+
+func (st *statistics) add(x float64) {
+	st.count++
+
+	// Neumaier summation
+	t := st.sum + x
+	if math.Abs(st.sum) >= math.Abs(x) {
+		st.compensation += (st.sum - t) + x
+	} else {
+		st.compensation += (x - t) + st.sum
+	}
+	st.sum = t
+
+	// Welford
+	delta := x - st.mean
+	st.mean += delta / float64(st.count)
+	st.m2 += delta * (x - st.mean)
+
+	if st.count == 1 || x < st.min {
+		st.min = x
+	}
+	if st.count == 1 || x > st.max {
+		st.max = x
+	}
+
+	st.median.add(x)
+	for _, q := range st.percentiles {
+		q.add(x)
+	}
+}
+
+func (st *statistics) variance() float64 {
+	if st.count < 2 {
+		return 0
+	}
+	return st.m2 / float64(st.count-1)
+}
+
+func (st *statistics) response() *calculatorpb.ComputeStatisticsResponse {
+	variance := st.variance()
+	res := &calculatorpb.ComputeStatisticsResponse{
+		Count:             st.count,
+		Sum:               st.sum + st.compensation,
+		Mean:              st.mean,
+		Min:               st.min,
+		Max:               st.max,
+		Variance:          variance,
+		StandardDeviation: math.Sqrt(variance),
+		Median:            st.value(st.median),
+	}
+	for _, q := range st.percentiles {
+		res.Percentiles = append(res.Percentiles, &calculatorpb.Percentile{
+			Percentile: q.percentile,
+			Value:      st.value(q),
+		})
+	}
+	return res
+}
+
+// value clamps the estimate to the observed range, the extremes are known exactly
+func (st *statistics) value(q *quantile) float64 {
+	switch {
+	case q.percentile == 0:
+		return st.min
+	case q.percentile == 100:
+		return st.max
+	}
+	return math.Max(st.min, math.Min(st.max, q.value()))
+}
+
+// quantile is a P² estimator (Jain & Chlamtac, 1985): it tracks a single
+// percentile with five markers instead of keeping every number around.
+type quantile struct {
+	percentile float64
+	p          float64
+	count      int
+	heights    [5]float64
+	positions  [5]float64
+	desired    [5]float64
+	increments [5]float64
+}
+
+func newQuantile(percentile float64) *quantile {
+	p := percentile / 100
+	return &quantile{
+		percentile: percentile,
+		p:          p,
+		positions:  [5]float64{1, 2, 3, 4, 5},
+		desired:    [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5},
+		increments: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+func (q *quantile) add(x float64) {
+	if q.count < 5 {
+		q.heights[q.count] = x
+		q.count++
+		if q.count == 5 {
+			sort.Float64s(q.heights[:])
+		}
+		return
+	}
+	q.count++
+
+	// find the cell k the number falls into, adjusting the extremes
+	var k int
+	switch {
+	case x < q.heights[0]:
+		q.heights[0] = x
+		k = 0
+	case x >= q.heights[4]:
+		q.heights[4] = x
+		k = 3
+	default:
+		for i := 1; i < 5; i++ {
+			if x < q.heights[i] {
+				k = i - 1
+				break
+			}
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		q.positions[i]++
+	}
+	for i := range q.desired {
+		q.desired[i] += q.increments[i]
+	}
+
+	// move the middle markers towards their desired positions
+	for i := 1; i < 4; i++ {
+		d := q.desired[i] - q.positions[i]
+		if (d >= 1 && q.positions[i+1]-q.positions[i] > 1) || (d <= -1 && q.positions[i-1]-q.positions[i] < -1) {
+			s := math.Copysign(1, d)
+			h := q.parabolic(i, s)
+			if q.heights[i-1] < h && h < q.heights[i+1] {
+				q.heights[i] = h
+			} else {
+				q.heights[i] = q.linear(i, s)
+			}
+			q.positions[i] += s
+		}
+	}
+}
+
+func (q *quantile) parabolic(i int, s float64) float64 {
+	n, h := q.positions, q.heights
+	return h[i] + s/(n[i+1]-n[i-1])*((n[i]-n[i-1]+s)*(h[i+1]-h[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-s)*(h[i]-h[i-1])/(n[i]-n[i-1]))
+}
+
+func (q *quantile) linear(i int, s float64) float64 {
+	j := i + int(s)
+	return q.heights[i] + s*(q.heights[j]-q.heights[i])/(q.positions[j]-q.positions[i])
+}
+
+func (q *quantile) value() float64 {
+	if q.count >= 5 {
+		return q.heights[2]
+	}
+	if q.count == 0 {
+		return 0
+	}
+	// too few numbers for the markers, interpolate over the sorted values
+	values := make([]float64, q.count)
+	copy(values, q.heights[:q.count])
+	sort.Float64s(values)
+	rank := q.p * float64(q.count-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return values[lower] + (rank-float64(lower))*(values[upper]-values[lower])
+}
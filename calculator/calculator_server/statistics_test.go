@@ -0,0 +1,183 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// exactPercentile interpolates between the closest ranks of sorted, like
+// quantile.value does with fewer than 5 numbers
+func exactPercentile(sorted []float64, percentile float64) float64 {
+	rank := percentile / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+func TestPercentiles(t *testing.T) {
+	// 1 to 1000 in a shuffled order, the exact percentiles are known
+	var shuffled []float64
+	for _, i := range rand.New(rand.NewSource(1)).Perm(1000) {
+		shuffled = append(shuffled, float64(i+1))
+	}
+	sorted := make([]float64, 1000)
+	for i := range sorted {
+		sorted[i] = float64(i + 1)
+	}
+
+	tests := []struct {
+		name        string
+		numbers     []float64
+		percentiles []float64
+		want        []float64
+		// P² is an estimate once there are 5 numbers or more
+		tolerance float64
+	}{
+		{
+			name:        "one number",
+			numbers:     []float64{4},
+			percentiles: []float64{0, 25, 50, 100},
+			want:        []float64{4, 4, 4, 4},
+		},
+		{
+			name:        "fewer than 5 numbers",
+			numbers:     []float64{3, 1, 4, 2},
+			percentiles: []float64{0, 25, 50, 75, 100},
+			want:        []float64{1, 1.75, 2.5, 3.25, 4},
+		},
+		{
+			name:        "exactly 5 numbers",
+			numbers:     []float64{5, 3, 1, 4, 2},
+			percentiles: []float64{0, 50, 100},
+			want:        []float64{1, 3, 5},
+		},
+		{
+			name:        "constant",
+			numbers:     []float64{7, 7, 7, 7, 7, 7, 7, 7, 7, 7},
+			percentiles: []float64{0, 25, 50, 90, 100},
+			want:        []float64{7, 7, 7, 7, 7},
+		},
+		{
+			name:        "1 to 1000 shuffled",
+			numbers:     shuffled,
+			percentiles: []float64{0, 25, 50, 75, 90, 99, 100},
+			want: []float64{
+				1,
+				exactPercentile(sorted, 25),
+				exactPercentile(sorted, 50),
+				exactPercentile(sorted, 75),
+				exactPercentile(sorted, 90),
+				exactPercentile(sorted, 99),
+				1000,
+			},
+			tolerance: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newStatistics(tt.percentiles)
+			for _, x := range tt.numbers {
+				st.add(x)
+			}
+			res := st.response()
+			if len(res.Percentiles) != len(tt.want) {
+				t.Fatalf("got %v percentiles, want %v", len(res.Percentiles), len(tt.want))
+			}
+			for i, p := range res.Percentiles {
+				if math.Abs(p.Value-tt.want[i]) > tt.tolerance {
+					t.Errorf("percentile %v = %v, want %v ± %v", p.Percentile, p.Value, tt.want[i], tt.tolerance)
+				}
+			}
+		})
+	}
+}
+
+func TestMoments(t *testing.T) {
+	tests := []struct {
+		name     string
+		numbers  []float64
+		sum      float64
+		mean     float64
+		variance float64
+		min, max float64
+	}{
+		{
+			name:    "one number",
+			numbers: []float64{-2},
+			sum:     -2, mean: -2, variance: 0, min: -2, max: -2,
+		},
+		{
+			name:    "constant",
+			numbers: []float64{7, 7, 7, 7, 7, 7},
+			sum:     42, mean: 7, variance: 0, min: 7, max: 7,
+		},
+		{
+			// the textbook formula cancels catastrophically on a large offset
+			name:    "variance with a large offset",
+			numbers: []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16},
+			sum:     4e9 + 40, mean: 1e9 + 10, variance: 30, min: 1e9 + 4, max: 1e9 + 16,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newStatistics(nil)
+			for _, x := range tt.numbers {
+				st.add(x)
+			}
+			res := st.response()
+			if res.Count != int64(len(tt.numbers)) {
+				t.Errorf("count = %v, want %v", res.Count, len(tt.numbers))
+			}
+			if res.Sum != tt.sum {
+				t.Errorf("sum = %v, want %v", res.Sum, tt.sum)
+			}
+			if !closeTo(res.Mean, tt.mean) {
+				t.Errorf("mean = %v, want %v", res.Mean, tt.mean)
+			}
+			if !closeTo(res.Variance, tt.variance) {
+				t.Errorf("variance = %v, want %v", res.Variance, tt.variance)
+			}
+			if res.Min != tt.min || res.Max != tt.max {
+				t.Errorf("min, max = %v, %v, want %v, %v", res.Min, res.Max, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	tenths := make([]float64, 1000)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+
+	tests := []struct {
+		name    string
+		numbers []float64
+		want    float64
+	}{
+		{"empty", nil, 0},
+		// a naive sum loses the 1 against 1e16
+		{"large magnitude", []float64{1e16, 1, -1e16}, 1},
+		{"large magnitude first", []float64{1e100, 1, 1, 1, -1e100}, 3},
+		{"large magnitude last", []float64{1, 1e100, 1, -1e100}, 2},
+		// a naive sum drifts to 99.9999999999986
+		{"many small numbers", tenths, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newStatistics(nil)
+			for _, x := range tt.numbers {
+				st.add(x)
+			}
+			if got := st.response().Sum; got != tt.want {
+				t.Errorf("sum = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// closeTo compares with a relative tolerance, an absolute one around 0
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+}
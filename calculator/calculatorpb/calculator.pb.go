@@ -6,6 +6,7 @@ package calculatorpb
 import (
 	context "context"
 	fmt "fmt"
+	_ "github.com/FernandoDevBh/grpc-go-course/validation/validationpb"
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
 	math "math"
@@ -22,6 +23,65 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Aggregate int32
+
+const (
+	Aggregate_AGGREGATE_UNSPECIFIED Aggregate = 0
+	Aggregate_MAX                   Aggregate = 1
+	Aggregate_MIN                   Aggregate = 2
+	Aggregate_SUM                   Aggregate = 3
+	Aggregate_MEAN                  Aggregate = 4
+)
+
+var Aggregate_name = map[int32]string{
+	0: "AGGREGATE_UNSPECIFIED",
+	1: "MAX",
+	2: "MIN",
+	3: "SUM",
+	4: "MEAN",
+}
+
+var Aggregate_value = map[string]int32{
+	"AGGREGATE_UNSPECIFIED": 0,
+	"MAX":                   1,
+	"MIN":                   2,
+	"SUM":                   3,
+	"MEAN":                  4,
+}
+
+func (x Aggregate) String() string {
+	return proto.EnumName(Aggregate_name, int32(x))
+}
+
+func (Aggregate) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{0}
+}
+
+type Window_Kind int32
+
+const (
+	Window_TUMBLING Window_Kind = 0
+	Window_SLIDING  Window_Kind = 1
+)
+
+var Window_Kind_name = map[int32]string{
+	0: "TUMBLING",
+	1: "SLIDING",
+}
+
+var Window_Kind_value = map[string]int32{
+	"TUMBLING": 0,
+	"SLIDING":  1,
+}
+
+func (x Window_Kind) String() string {
+	return proto.EnumName(Window_Kind_name, int32(x))
+}
+
+func (Window_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{11, 0}
+}
+
 type Sum struct {
 	FirstNumber          float32  `protobuf:"fixed32,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
 	SecondNumber         float32  `protobuf:"fixed32,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
@@ -459,6 +519,187 @@ func (m *FindMaximumResponse) GetMaximum() int32 {
 	return 0
 }
 
+type Window struct {
+	Kind Window_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=calculator.Window_Kind" json:"kind,omitempty"`
+	// count based window: how many numbers are aggregated together
+	Size int32 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// time based window, used when size is not set: from 10ms to 1 hour,
+	// holding at most 100000 numbers
+	DurationMs int64 `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// how often a time based sliding window is emitted, defaults to a tenth of
+	// the duration and at least 10ms. Only allowed on time based sliding windows.
+	SlideMs              int64    `protobuf:"varint,4,opt,name=slide_ms,json=slideMs,proto3" json:"slide_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Window) Reset()         { *m = Window{} }
+func (m *Window) String() string { return proto.CompactTextString(m) }
+func (*Window) ProtoMessage()    {}
+func (*Window) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{11}
+}
+
+func (m *Window) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Window.Unmarshal(m, b)
+}
+func (m *Window) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Window.Marshal(b, m, deterministic)
+}
+func (m *Window) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Window.Merge(m, src)
+}
+func (m *Window) XXX_Size() int {
+	return xxx_messageInfo_Window.Size(m)
+}
+func (m *Window) XXX_DiscardUnknown() {
+	xxx_messageInfo_Window.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Window proto.InternalMessageInfo
+
+func (m *Window) GetKind() Window_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return Window_TUMBLING
+}
+
+func (m *Window) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Window) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *Window) GetSlideMs() int64 {
+	if m != nil {
+		return m.SlideMs
+	}
+	return 0
+}
+
+type RollingAggregateRequest struct {
+	Number float64 `protobuf:"fixed64,1,opt,name=number,proto3" json:"number,omitempty"`
+	// the aggregate and the window are only read from the first message of the stream
+	Aggregate            Aggregate `protobuf:"varint,2,opt,name=aggregate,proto3,enum=calculator.Aggregate" json:"aggregate,omitempty"`
+	Window               *Window   `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RollingAggregateRequest) Reset()         { *m = RollingAggregateRequest{} }
+func (m *RollingAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*RollingAggregateRequest) ProtoMessage()    {}
+func (*RollingAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{12}
+}
+
+func (m *RollingAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollingAggregateRequest.Unmarshal(m, b)
+}
+func (m *RollingAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollingAggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *RollingAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollingAggregateRequest.Merge(m, src)
+}
+func (m *RollingAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_RollingAggregateRequest.Size(m)
+}
+func (m *RollingAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollingAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollingAggregateRequest proto.InternalMessageInfo
+
+func (m *RollingAggregateRequest) GetNumber() float64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *RollingAggregateRequest) GetAggregate() Aggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return Aggregate_AGGREGATE_UNSPECIFIED
+}
+
+func (m *RollingAggregateRequest) GetWindow() *Window {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+type RollingAggregateResponse struct {
+	// sequence number of the window, starting at 1
+	Window int64   `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	Value  float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	// how many numbers the window holds
+	Count                int64    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RollingAggregateResponse) Reset()         { *m = RollingAggregateResponse{} }
+func (m *RollingAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*RollingAggregateResponse) ProtoMessage()    {}
+func (*RollingAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{13}
+}
+
+func (m *RollingAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollingAggregateResponse.Unmarshal(m, b)
+}
+func (m *RollingAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollingAggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *RollingAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollingAggregateResponse.Merge(m, src)
+}
+func (m *RollingAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_RollingAggregateResponse.Size(m)
+}
+func (m *RollingAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollingAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RollingAggregateResponse proto.InternalMessageInfo
+
+func (m *RollingAggregateResponse) GetWindow() int64 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+func (m *RollingAggregateResponse) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *RollingAggregateResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 type SquareRootRequest struct {
 	Number               int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -470,7 +711,7 @@ func (m *SquareRootRequest) Reset()         { *m = SquareRootRequest{} }
 func (m *SquareRootRequest) String() string { return proto.CompactTextString(m) }
 func (*SquareRootRequest) ProtoMessage()    {}
 func (*SquareRootRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{11}
+	return fileDescriptor_7f42938f8c8365cf, []int{14}
 }
 
 func (m *SquareRootRequest) XXX_Unmarshal(b []byte) error {
@@ -509,7 +750,7 @@ func (m *SquareRootResponse) Reset()         { *m = SquareRootResponse{} }
 func (m *SquareRootResponse) String() string { return proto.CompactTextString(m) }
 func (*SquareRootResponse) ProtoMessage()    {}
 func (*SquareRootResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7f42938f8c8365cf, []int{12}
+	return fileDescriptor_7f42938f8c8365cf, []int{15}
 }
 
 func (m *SquareRootResponse) XXX_Unmarshal(b []byte) error {
@@ -537,7 +778,208 @@ func (m *SquareRootResponse) GetNumberRoot() float64 {
 	return 0
 }
 
+type ComputeStatisticsRequest struct {
+	Number float64 `protobuf:"fixed64,1,opt,name=number,proto3" json:"number,omitempty"`
+	// percentiles (0 - 100) to estimate, only read from the first message of the stream
+	Percentiles          []float64 `protobuf:"fixed64,2,rep,packed,name=percentiles,proto3" json:"percentiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ComputeStatisticsRequest) Reset()         { *m = ComputeStatisticsRequest{} }
+func (m *ComputeStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*ComputeStatisticsRequest) ProtoMessage()    {}
+func (*ComputeStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{16}
+}
+
+func (m *ComputeStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStatisticsRequest.Unmarshal(m, b)
+}
+func (m *ComputeStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *ComputeStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStatisticsRequest.Merge(m, src)
+}
+func (m *ComputeStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_ComputeStatisticsRequest.Size(m)
+}
+func (m *ComputeStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStatisticsRequest proto.InternalMessageInfo
+
+func (m *ComputeStatisticsRequest) GetNumber() float64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsRequest) GetPercentiles() []float64 {
+	if m != nil {
+		return m.Percentiles
+	}
+	return nil
+}
+
+type Percentile struct {
+	Percentile           float64  `protobuf:"fixed64,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Percentile) Reset()         { *m = Percentile{} }
+func (m *Percentile) String() string { return proto.CompactTextString(m) }
+func (*Percentile) ProtoMessage()    {}
+func (*Percentile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{17}
+}
+
+func (m *Percentile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Percentile.Unmarshal(m, b)
+}
+func (m *Percentile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Percentile.Marshal(b, m, deterministic)
+}
+func (m *Percentile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Percentile.Merge(m, src)
+}
+func (m *Percentile) XXX_Size() int {
+	return xxx_messageInfo_Percentile.Size(m)
+}
+func (m *Percentile) XXX_DiscardUnknown() {
+	xxx_messageInfo_Percentile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Percentile proto.InternalMessageInfo
+
+func (m *Percentile) GetPercentile() float64 {
+	if m != nil {
+		return m.Percentile
+	}
+	return 0
+}
+
+func (m *Percentile) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ComputeStatisticsResponse struct {
+	Count int64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Sum   float64 `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Mean  float64 `protobuf:"fixed64,3,opt,name=mean,proto3" json:"mean,omitempty"`
+	Min   float64 `protobuf:"fixed64,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max   float64 `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	// sample variance (n - 1), 0 when a single number was sent
+	Variance             float64       `protobuf:"fixed64,6,opt,name=variance,proto3" json:"variance,omitempty"`
+	StandardDeviation    float64       `protobuf:"fixed64,7,opt,name=standard_deviation,json=standardDeviation,proto3" json:"standard_deviation,omitempty"`
+	Median               float64       `protobuf:"fixed64,8,opt,name=median,proto3" json:"median,omitempty"`
+	Percentiles          []*Percentile `protobuf:"bytes,9,rep,name=percentiles,proto3" json:"percentiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ComputeStatisticsResponse) Reset()         { *m = ComputeStatisticsResponse{} }
+func (m *ComputeStatisticsResponse) String() string { return proto.CompactTextString(m) }
+func (*ComputeStatisticsResponse) ProtoMessage()    {}
+func (*ComputeStatisticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f42938f8c8365cf, []int{18}
+}
+
+func (m *ComputeStatisticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStatisticsResponse.Unmarshal(m, b)
+}
+func (m *ComputeStatisticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStatisticsResponse.Marshal(b, m, deterministic)
+}
+func (m *ComputeStatisticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStatisticsResponse.Merge(m, src)
+}
+func (m *ComputeStatisticsResponse) XXX_Size() int {
+	return xxx_messageInfo_ComputeStatisticsResponse.Size(m)
+}
+func (m *ComputeStatisticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStatisticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStatisticsResponse proto.InternalMessageInfo
+
+func (m *ComputeStatisticsResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMean() float64 {
+	if m != nil {
+		return m.Mean
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetVariance() float64 {
+	if m != nil {
+		return m.Variance
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetStandardDeviation() float64 {
+	if m != nil {
+		return m.StandardDeviation
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMedian() float64 {
+	if m != nil {
+		return m.Median
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetPercentiles() []*Percentile {
+	if m != nil {
+		return m.Percentiles
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("calculator.Aggregate", Aggregate_name, Aggregate_value)
+	proto.RegisterEnum("calculator.Window_Kind", Window_Kind_name, Window_Kind_value)
 	proto.RegisterType((*Sum)(nil), "calculator.Sum")
 	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
 	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
@@ -549,8 +991,14 @@ func init() {
 	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
 	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
 	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
+	proto.RegisterType((*Window)(nil), "calculator.Window")
+	proto.RegisterType((*RollingAggregateRequest)(nil), "calculator.RollingAggregateRequest")
+	proto.RegisterType((*RollingAggregateResponse)(nil), "calculator.RollingAggregateResponse")
 	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
 	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+	proto.RegisterType((*ComputeStatisticsRequest)(nil), "calculator.ComputeStatisticsRequest")
+	proto.RegisterType((*Percentile)(nil), "calculator.Percentile")
+	proto.RegisterType((*ComputeStatisticsResponse)(nil), "calculator.ComputeStatisticsResponse")
 }
 
 func init() {
@@ -558,38 +1006,70 @@ func init() {
 }
 
 var fileDescriptor_7f42938f8c8365cf = []byte{
-	// 492 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5d, 0x6f, 0xd3, 0x30,
-	0x14, 0x25, 0xab, 0x18, 0xd2, 0x4d, 0x19, 0xea, 0x45, 0x94, 0x2a, 0x12, 0xeb, 0x6a, 0x84, 0x54,
-	0x69, 0xd3, 0x3a, 0x15, 0x21, 0xf1, 0x0a, 0x83, 0xbd, 0xa0, 0x4e, 0x28, 0xe5, 0x09, 0x1e, 0xaa,
-	0x34, 0xf3, 0xa6, 0x48, 0x4d, 0x9c, 0x39, 0xf6, 0x04, 0x7f, 0x91, 0x5f, 0x85, 0xe2, 0x8f, 0xc6,
-	0xa9, 0x1b, 0xe0, 0x2d, 0xf7, 0xf8, 0xf8, 0xdc, 0x73, 0x3f, 0x62, 0x98, 0xa6, 0xc9, 0x26, 0x95,
-	0x9b, 0x44, 0x30, 0x3e, 0x6b, 0x3e, 0xcb, 0xb5, 0x13, 0x9c, 0x97, 0x9c, 0x09, 0x86, 0xd0, 0x20,
-	0x64, 0x01, 0xbd, 0xa5, 0xcc, 0x71, 0x02, 0xfd, 0xdb, 0x8c, 0x57, 0x62, 0x55, 0xc8, 0x7c, 0x4d,
-	0xf9, 0x28, 0x38, 0x09, 0xa6, 0x07, 0x71, 0xa8, 0xb0, 0x6b, 0x05, 0xe1, 0x6b, 0x78, 0x5a, 0xd1,
-	0x94, 0x15, 0x37, 0x96, 0x73, 0xa0, 0x38, 0x7d, 0x0d, 0x6a, 0x12, 0x99, 0x01, 0x2c, 0x65, 0x1e,
-	0xd3, 0x7b, 0x49, 0x2b, 0x81, 0x13, 0xe8, 0x55, 0x32, 0x57, 0x62, 0xe1, 0xfc, 0xd9, 0xb9, 0x63,
-	0xa4, 0x26, 0xd5, 0x67, 0xe4, 0x0d, 0x84, 0xea, 0x42, 0x55, 0xb2, 0xa2, 0xa2, 0x38, 0x84, 0x43,
-	0x4e, 0x2b, 0xb9, 0x11, 0xc6, 0x81, 0x89, 0xc8, 0x29, 0x0c, 0xbe, 0xf2, 0x2c, 0xa7, 0x9f, 0x68,
-	0xca, 0xf2, 0x92, 0x55, 0x99, 0x60, 0x45, 0x4d, 0x76, 0xec, 0xf6, 0x62, 0x13, 0x91, 0x33, 0xc0,
-	0x0f, 0x0f, 0x94, 0x27, 0x77, 0xf4, 0xd2, 0x90, 0x33, 0x8f, 0x1d, 0x6c, 0xd9, 0x05, 0x8c, 0x95,
-	0xb4, 0xae, 0xa0, 0x49, 0x90, 0xb1, 0xc2, 0xd6, 0xf1, 0x05, 0x06, 0xe5, 0x6e, 0x76, 0x53, 0xd5,
-	0x2b, 0xb7, 0x2a, 0xcf, 0x62, 0xec, 0xdf, 0x23, 0x9f, 0xe1, 0xa4, 0x3b, 0x9f, 0x69, 0xc3, 0x04,
-	0xfa, 0xea, 0xe2, 0xea, 0x36, 0x49, 0x05, 0xb3, 0xf5, 0x85, 0x0a, 0xbb, 0x52, 0x10, 0xb9, 0x83,
-	0x17, 0x75, 0x75, 0x52, 0x50, 0x53, 0xab, 0x35, 0x7b, 0x0d, 0x98, 0x78, 0xd5, 0x1b, 0xb7, 0xc7,
-	0xae, 0x5b, 0xbf, 0x47, 0xf1, 0x9e, 0x9b, 0x64, 0x0e, 0xc3, 0xdd, 0x44, 0xc6, 0xe5, 0x08, 0x9e,
-	0x18, 0xbe, 0x69, 0xa9, 0x0d, 0xeb, 0x09, 0x5c, 0x65, 0xc5, 0xcd, 0x22, 0xf9, 0x99, 0xe5, 0xcd,
-	0x3a, 0xb4, 0x27, 0xf0, 0x78, 0x3b, 0x81, 0x19, 0x3c, 0x6f, 0xb1, 0x1b, 0xf9, 0x5c, 0x43, 0x86,
-	0x6f, 0xc3, 0x7a, 0x1b, 0x96, 0xf7, 0x32, 0xe1, 0x34, 0x66, 0x4c, 0xfc, 0x4b, 0xfd, 0x1d, 0xa0,
-	0x4b, 0x36, 0xe2, 0x63, 0x08, 0xf5, 0xf9, 0x8a, 0x33, 0x26, 0x8c, 0x7f, 0xd0, 0x50, 0x4d, 0x9c,
-	0xff, 0xee, 0xc1, 0xe0, 0x72, 0xdb, 0xac, 0x25, 0xe5, 0x0f, 0x59, 0x4a, 0xf1, 0xbd, 0xfe, 0x5d,
-	0x86, 0xbb, 0xbb, 0xac, 0x3d, 0x44, 0x2f, 0x3d, 0x5c, 0xa7, 0x23, 0x8f, 0xf0, 0x17, 0x8c, 0xba,
-	0xc6, 0x8e, 0xa7, 0xde, 0x12, 0x75, 0x2f, 0x63, 0x74, 0xf6, 0x7f, 0x64, 0x9b, 0xf8, 0x22, 0xc0,
-	0x1f, 0x70, 0xd4, 0x9e, 0x20, 0x4e, 0x5c, 0x8d, 0xbd, 0x6b, 0x14, 0x91, 0xbf, 0x51, 0xac, 0xf8,
-	0x34, 0xc0, 0x6f, 0x10, 0x3a, 0xc3, 0xc3, 0xd6, 0x86, 0xf9, 0x3b, 0x10, 0x8d, 0x3b, 0xcf, 0x1b,
-	0xcd, 0x8b, 0x00, 0x17, 0x00, 0xcd, 0xd0, 0xb0, 0xf5, 0x93, 0x79, 0x93, 0x8f, 0x8e, 0xbb, 0x8e,
-	0xad, 0xe4, 0xc7, 0xa3, 0xef, 0x7d, 0xf7, 0x49, 0x5c, 0x1f, 0xaa, 0x87, 0xf0, 0xed, 0x9f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x73, 0x8e, 0x2d, 0x27, 0x34, 0x05, 0x00, 0x00,
+	// 1000 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x6d, 0x4f, 0x1b, 0x47,
+	0x10, 0xe6, 0x6c, 0x63, 0xcc, 0x1c, 0xa1, 0xf6, 0xb4, 0xc0, 0x61, 0xa9, 0xbc, 0x5c, 0x82, 0xe4,
+	0x86, 0x14, 0x22, 0x47, 0x55, 0xa3, 0x7e, 0xa9, 0x0c, 0x18, 0x84, 0x12, 0x5b, 0xe8, 0x0e, 0xda,
+	0xaa, 0x95, 0x6a, 0x2d, 0x77, 0x1b, 0x6b, 0x55, 0xdf, 0xad, 0x73, 0x2f, 0x0e, 0xed, 0xc7, 0xfc,
+	0x04, 0xa4, 0x4a, 0xfd, 0x09, 0xfd, 0x79, 0xfd, 0x09, 0xd5, 0xed, 0xed, 0xbd, 0x71, 0x76, 0x88,
+	0x3f, 0xed, 0xcc, 0x3c, 0xf3, 0xf6, 0xec, 0xdc, 0xac, 0xa1, 0x63, 0x91, 0x89, 0x15, 0x4e, 0x48,
+	0xc0, 0xbd, 0xe3, 0xec, 0x38, 0xbd, 0xcd, 0x09, 0x47, 0x53, 0x8f, 0x07, 0x1c, 0x21, 0xd3, 0xb4,
+	0x3b, 0x33, 0x32, 0x61, 0x36, 0x09, 0x18, 0x77, 0x8f, 0xb3, 0xe3, 0xf4, 0x36, 0x27, 0xc4, 0x5e,
+	0xfa, 0x00, 0xaa, 0x66, 0xe8, 0xe0, 0x3e, 0xac, 0xbd, 0x63, 0x9e, 0x1f, 0x8c, 0xdc, 0xd0, 0xb9,
+	0xa5, 0x9e, 0xa6, 0xec, 0x29, 0x9d, 0x8a, 0xa1, 0x0a, 0xdd, 0x50, 0xa8, 0xf0, 0x29, 0x3c, 0xf1,
+	0xa9, 0xc5, 0x5d, 0x3b, 0xc1, 0x54, 0x04, 0x66, 0x2d, 0x56, 0xc6, 0x20, 0xfd, 0x7b, 0x00, 0x33,
+	0x74, 0x0c, 0xfa, 0x3e, 0xa4, 0x7e, 0x80, 0xdf, 0x40, 0xd5, 0x0f, 0x1d, 0x11, 0x4c, 0xed, 0x7e,
+	0x71, 0x94, 0x2b, 0xd9, 0x0c, 0x9d, 0x93, 0xfa, 0xfd, 0xc7, 0xed, 0x4a, 0x43, 0x31, 0x22, 0x8c,
+	0x7e, 0x00, 0xaa, 0x70, 0xf4, 0xa7, 0xdc, 0xf5, 0x29, 0x6e, 0x42, 0xdd, 0xa3, 0x7e, 0x38, 0x09,
+	0x64, 0x25, 0x52, 0xd2, 0x7f, 0x80, 0xd6, 0x95, 0xc7, 0x1c, 0x7a, 0x46, 0x2d, 0xee, 0x4c, 0xb9,
+	0xcf, 0x02, 0xee, 0xe2, 0x01, 0xd4, 0x73, 0x65, 0x57, 0x4f, 0x9e, 0xdc, 0x7f, 0xdc, 0x5e, 0xdd,
+	0x5f, 0x12, 0xbf, 0xff, 0x7e, 0x34, 0xa4, 0x51, 0x7f, 0x01, 0xd8, 0x9b, 0x51, 0x8f, 0x8c, 0xe9,
+	0xa9, 0xf4, 0x65, 0xdc, 0x8d, 0x32, 0xe5, 0x9c, 0x95, 0x14, 0x3d, 0x83, 0x5d, 0x91, 0x29, 0x6e,
+	0x2c, 0xcb, 0xc7, 0xb8, 0x9b, 0xb4, 0x67, 0x42, 0x6b, 0xfa, 0xb0, 0x18, 0xd9, 0xec, 0xd7, 0xf9,
+	0x66, 0x4b, 0x15, 0xa7, 0xad, 0x97, 0xfd, 0xf5, 0x3e, 0xec, 0x2d, 0xce, 0x2b, 0xd9, 0xd9, 0x87,
+	0x35, 0xe1, 0x38, 0x7a, 0x47, 0xac, 0x80, 0xcb, 0xb6, 0x0d, 0x55, 0xe8, 0xce, 0x85, 0x4a, 0xe7,
+	0xb0, 0x11, 0x75, 0x19, 0x06, 0x54, 0xf6, 0x9c, 0x14, 0xfd, 0x13, 0x20, 0x29, 0xb1, 0x20, 0xab,
+	0xde, 0xc9, 0x57, 0x5d, 0xe6, 0x2a, 0x2d, 0x7b, 0x4e, 0x04, 0xbd, 0x0b, 0x9b, 0x0f, 0x13, 0xca,
+	0x6a, 0x35, 0x58, 0x91, 0x78, 0x49, 0x71, 0x22, 0x46, 0x37, 0x72, 0xce, 0x5c, 0x7b, 0x40, 0xee,
+	0x98, 0x93, 0x4d, 0x4d, 0xf1, 0x46, 0x96, 0xd3, 0x1b, 0x39, 0x86, 0x2f, 0x0b, 0xe8, 0x2c, 0xbc,
+	0x13, 0xab, 0x24, 0x3e, 0x11, 0xf5, 0x7f, 0x15, 0xa8, 0xff, 0xcc, 0x5c, 0x9b, 0x7f, 0xc0, 0x43,
+	0xa8, 0xfd, 0xc1, 0x5c, 0x5b, 0x20, 0xd6, 0xbb, 0x5b, 0xf9, 0x3e, 0x63, 0xc4, 0xd1, 0x1b, 0xe6,
+	0xda, 0x86, 0x00, 0x21, 0x42, 0xcd, 0x67, 0x7f, 0x51, 0x31, 0xe0, 0xcb, 0x86, 0x38, 0xe3, 0x2e,
+	0xa8, 0x76, 0xe8, 0x89, 0x2f, 0x67, 0xe4, 0xf8, 0x5a, 0x55, 0x30, 0x0e, 0x89, 0x6a, 0xe0, 0xe3,
+	0x36, 0x34, 0xfc, 0x09, 0xb3, 0x69, 0x64, 0xad, 0x09, 0xeb, 0x8a, 0x90, 0x07, 0xbe, 0xbe, 0x0f,
+	0xb5, 0x28, 0x3a, 0xae, 0x41, 0xe3, 0xfa, 0x66, 0x70, 0xf2, 0xf6, 0x72, 0x78, 0xd1, 0x5c, 0x42,
+	0x15, 0x56, 0xcc, 0xb7, 0x97, 0x67, 0x91, 0xa0, 0xe8, 0xf7, 0x0a, 0x6c, 0x19, 0x7c, 0x32, 0x61,
+	0xee, 0xb8, 0x37, 0x1e, 0x7b, 0x74, 0x4c, 0x02, 0x3a, 0x9f, 0x8f, 0x74, 0x42, 0xf1, 0x15, 0xac,
+	0x92, 0x04, 0x2b, 0x6a, 0x5d, 0xef, 0x6e, 0x14, 0x2e, 0x30, 0x0d, 0x94, 0xe1, 0xf0, 0x39, 0xd4,
+	0x3f, 0x88, 0x86, 0x45, 0x0b, 0x6a, 0x17, 0xcb, 0x54, 0x18, 0x12, 0xa1, 0xff, 0x0e, 0x5a, 0xb9,
+	0xa6, 0xec, 0x03, 0x95, 0x71, 0xe2, 0xe1, 0x93, 0x12, 0x7e, 0x05, 0xcb, 0x33, 0x32, 0x09, 0xe3,
+	0x82, 0x14, 0x23, 0x16, 0x22, 0xad, 0xc5, 0x43, 0x37, 0x90, 0xbc, 0xc5, 0x82, 0x7e, 0x08, 0x2d,
+	0xf3, 0x7d, 0x48, 0x3c, 0x6a, 0x70, 0x1e, 0x3c, 0x76, 0xfb, 0xdf, 0x01, 0xe6, 0xc1, 0xb2, 0x8c,
+	0x5d, 0x50, 0x63, 0xfb, 0xc8, 0xe3, 0x3c, 0x90, 0x04, 0x41, 0xac, 0x8a, 0x80, 0xfa, 0x35, 0x68,
+	0x72, 0x2c, 0xcd, 0x80, 0x04, 0xcc, 0x0f, 0x98, 0xe5, 0x3f, 0x46, 0xec, 0x1e, 0xa8, 0x53, 0xea,
+	0x59, 0xd4, 0x0d, 0xd8, 0x84, 0xfa, 0x5a, 0x65, 0xaf, 0xda, 0x51, 0x8c, 0xbc, 0x4a, 0x3f, 0x01,
+	0xb8, 0x4a, 0x45, 0xdc, 0x01, 0xc8, 0x8c, 0x49, 0x0d, 0x99, 0x66, 0x3e, 0x27, 0xfa, 0x3f, 0x15,
+	0xd8, 0x9e, 0x53, 0x9a, 0x6c, 0x2c, 0x65, 0x4c, 0xc9, 0x31, 0x86, 0xcd, 0x78, 0xa1, 0xc6, 0x71,
+	0xa2, 0x63, 0x34, 0xab, 0x0e, 0x25, 0xae, 0x20, 0x56, 0x31, 0xc4, 0x39, 0x42, 0x39, 0xcc, 0x15,
+	0x53, 0xa8, 0x18, 0xd1, 0x51, 0x68, 0xc8, 0x9d, 0xb6, 0x2c, 0x35, 0xe4, 0x0e, 0xdb, 0xd0, 0x98,
+	0x11, 0x8f, 0x11, 0xd7, 0xa2, 0x5a, 0x5d, 0xa8, 0x53, 0x19, 0xbf, 0x05, 0xf4, 0x03, 0xe2, 0xda,
+	0xc4, 0xb3, 0x47, 0x36, 0x9d, 0x31, 0x31, 0xe2, 0xda, 0x8a, 0x40, 0xb5, 0x12, 0xcb, 0x59, 0x62,
+	0x88, 0x68, 0x74, 0xa8, 0xcd, 0x88, 0xab, 0x35, 0x62, 0x1a, 0x63, 0x09, 0x5f, 0x17, 0x69, 0x5c,
+	0xdd, 0xab, 0x76, 0xd4, 0xee, 0x66, 0x61, 0x31, 0xa6, 0xe6, 0x02, 0xbd, 0xcf, 0xdf, 0xc0, 0x6a,
+	0x3a, 0x71, 0xb8, 0x0d, 0x1b, 0xbd, 0x8b, 0x0b, 0xa3, 0x7f, 0xd1, 0xbb, 0xee, 0x8f, 0x6e, 0x86,
+	0xe6, 0x55, 0xff, 0xf4, 0xf2, 0xfc, 0xb2, 0x7f, 0xd6, 0x5c, 0xc2, 0x15, 0xa8, 0x0e, 0x7a, 0xbf,
+	0x34, 0x15, 0x71, 0xb8, 0x1c, 0x36, 0x2b, 0xd1, 0xc1, 0xbc, 0x19, 0x34, 0xab, 0xd8, 0x80, 0xda,
+	0xa0, 0xdf, 0x1b, 0x36, 0x6b, 0xdd, 0xbf, 0x97, 0xa1, 0x75, 0x9a, 0xe6, 0x34, 0xa9, 0x37, 0x63,
+	0x16, 0xc5, 0xd7, 0xf1, 0xbb, 0xb7, 0xf9, 0xe0, 0x51, 0x92, 0xa3, 0xd1, 0xde, 0x2a, 0xe9, 0xe3,
+	0x7b, 0xd1, 0x97, 0xf0, 0x4f, 0xd0, 0x16, 0x2d, 0x68, 0x3c, 0x2c, 0xad, 0xfd, 0xc5, 0xcf, 0x47,
+	0xfb, 0xc5, 0xe7, 0x81, 0x93, 0xc4, 0x2f, 0x15, 0xfc, 0x0d, 0xd6, 0x8b, 0x3b, 0x16, 0xf7, 0xf3,
+	0x31, 0xe6, 0x2e, 0xfc, 0xb6, 0xfe, 0x29, 0x48, 0x12, 0xbc, 0xa3, 0xa0, 0x0d, 0xad, 0xd2, 0x38,
+	0xe2, 0xb3, 0x39, 0xce, 0xa5, 0x0f, 0xa9, 0x7d, 0xf0, 0x08, 0x2a, 0x97, 0xe5, 0x1a, 0xd4, 0xdc,
+	0x12, 0xc7, 0xc2, 0x8b, 0x53, 0x7e, 0x0b, 0xda, 0xbb, 0x0b, 0xed, 0x59, 0xcc, 0x97, 0x0a, 0x5a,
+	0xd0, 0x7c, 0xb8, 0xa9, 0xf0, 0x69, 0xde, 0x75, 0xc1, 0x6e, 0x6d, 0x3f, 0xfb, 0x34, 0xa8, 0x90,
+	0x64, 0x00, 0x90, 0x6d, 0x20, 0x2c, 0xbc, 0xf0, 0xa5, 0x35, 0xd6, 0xde, 0x59, 0x64, 0x4e, 0x42,
+	0x9e, 0xac, 0xff, 0xba, 0x96, 0xff, 0x43, 0x77, 0x5b, 0x17, 0x7f, 0xc8, 0x5e, 0xfd, 0x3f, 0x00,
+	0xd2, 0x47, 0x1d, 0x10, 0xf2, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -610,8 +1090,16 @@ type CalculatorServiceClient interface {
 	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
 	// Client Streaming
 	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
+	// Client Streaming
+	// this RPC will throw an exception if the stream is empty or a percentile is out of range
+	// the error being sent is of type INVALID_ARGUMENT
+	ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error)
 	// BiDi Streaming
 	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+	// BiDi Streaming
+	// emits the aggregate of every window, INVALID_ARGUMENT if the first message has no aggregate or window,
+	// RESOURCE_EXHAUSTED if a time based window gets more numbers than it can hold
+	RollingAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_RollingAggregateClient, error)
 	// Error handling
 	// this RPC will throw an exception if the send number is negative
 	// the error being sent is of type INVALID_ARGUMENT
@@ -701,8 +1189,42 @@ func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageR
 	return m, nil
 }
 
+func (c *calculatorServiceClient) ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/ComputeStatistics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeStatisticsClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeStatisticsClient interface {
+	Send(*ComputeStatisticsRequest) error
+	CloseAndRecv() (*ComputeStatisticsResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeStatisticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeStatisticsClient) Send(m *ComputeStatisticsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsClient) CloseAndRecv() (*ComputeStatisticsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeStatisticsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/FindMaximum", opts...)
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/FindMaximum", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -732,6 +1254,37 @@ func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error
 	return m, nil
 }
 
+func (c *calculatorServiceClient) RollingAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_RollingAggregateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[4], "/calculator.CalculatorService/RollingAggregate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceRollingAggregateClient{stream}
+	return x, nil
+}
+
+type CalculatorService_RollingAggregateClient interface {
+	Send(*RollingAggregateRequest) error
+	Recv() (*RollingAggregateResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceRollingAggregateClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceRollingAggregateClient) Send(m *RollingAggregateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceRollingAggregateClient) Recv() (*RollingAggregateResponse, error) {
+	m := new(RollingAggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
 	out := new(SquareRootResponse)
 	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
@@ -749,8 +1302,16 @@ type CalculatorServiceServer interface {
 	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
 	// Client Streaming
 	ComputeAverage(CalculatorService_ComputeAverageServer) error
+	// Client Streaming
+	// this RPC will throw an exception if the stream is empty or a percentile is out of range
+	// the error being sent is of type INVALID_ARGUMENT
+	ComputeStatistics(CalculatorService_ComputeStatisticsServer) error
 	// BiDi Streaming
 	FindMaximum(CalculatorService_FindMaximumServer) error
+	// BiDi Streaming
+	// emits the aggregate of every window, INVALID_ARGUMENT if the first message has no aggregate or window,
+	// RESOURCE_EXHAUSTED if a time based window gets more numbers than it can hold
+	RollingAggregate(CalculatorService_RollingAggregateServer) error
 	// Error handling
 	// this RPC will throw an exception if the send number is negative
 	// the error being sent is of type INVALID_ARGUMENT
@@ -826,6 +1387,32 @@ func (x *calculatorServiceComputeAverageServer) Recv() (*ComputeAverageRequest,
 	return m, nil
 }
 
+func _CalculatorService_ComputeStatistics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ComputeStatistics(&calculatorServiceComputeStatisticsServer{stream})
+}
+
+type CalculatorService_ComputeStatisticsServer interface {
+	SendAndClose(*ComputeStatisticsResponse) error
+	Recv() (*ComputeStatisticsRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceComputeStatisticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceComputeStatisticsServer) SendAndClose(m *ComputeStatisticsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsServer) Recv() (*ComputeStatisticsRequest, error) {
+	m := new(ComputeStatisticsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
 }
@@ -852,6 +1439,32 @@ func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error)
 	return m, nil
 }
 
+func _CalculatorService_RollingAggregate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).RollingAggregate(&calculatorServiceRollingAggregateServer{stream})
+}
+
+type CalculatorService_RollingAggregateServer interface {
+	Send(*RollingAggregateResponse) error
+	Recv() (*RollingAggregateRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceRollingAggregateServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceRollingAggregateServer) Send(m *RollingAggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceRollingAggregateServer) Recv() (*RollingAggregateRequest, error) {
+	m := new(RollingAggregateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SquareRootRequest)
 	if err := dec(in); err != nil {
@@ -894,12 +1507,23 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _CalculatorService_ComputeAverage_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ComputeStatistics",
+			Handler:       _CalculatorService_ComputeStatistics_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "FindMaximum",
 			Handler:       _CalculatorService_FindMaximum_Handler,
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "RollingAggregate",
+			Handler:       _CalculatorService_RollingAggregate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "calculator/calculatorpb/calculator.proto",
 }
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"google.golang.org/grpc/status"
+)
+
+// benchResult is the report of rpc bench, also written as JSON with --out
+type benchResult struct {
+	Method      string         `json:"method"`
+	Concurrency int            `json:"concurrency"`
+	TargetQPS   float64        `json:"target_qps,omitempty"`
+	Duration    float64        `json:"duration_seconds"`
+	Calls       int            `json:"calls"`
+	Errors      int            `json:"errors"`
+	Responses   int            `json:"responses"`
+	Throughput  float64        `json:"throughput"`
+	Latency     latencySummary `json:"latency_ms"`
+	StatusCodes map[string]int `json:"status_codes"`
+}
+
+type latencySummary struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// highest --qps, the pace is kept by a ticker that cannot tick faster
+const maxBenchQPS = 1e6
+
+// benchWorker keeps the results of one worker, merged at the end of the run
+type benchWorker struct {
+	latencies []time.Duration
+	codes     map[string]int
+	responses int
+}
+
+func rpcBench(e *env, args []string) error {
+	fs := flag.NewFlagSet("rpc bench", flag.ExitOnError)
+	concurrency := fs.Int("concurrency", 1, "calls in flight at the same time")
+	qps := fs.Float64("qps", 0, "target calls per second across all workers, as fast as possible when 0")
+	duration := fs.Duration("duration", 10*time.Second, "how long to run")
+	timeout := fs.Duration("timeout", 0, "deadline of every call, none when 0")
+	outFile := fs.String("out", "", "also write the results as JSON to this file")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("rpc bench needs a method and an optional JSON request")
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if !(*qps >= 0 && *qps <= maxBenchQPS) {
+		return fmt.Errorf("--qps must be between 0 and %v", maxBenchQPS)
+	}
+	if *duration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+
+	rc := newReflectionClient(e)
+	defer rc.Reset()
+
+	md, err := resolveMethod(rc, args[0])
+	if err != nil {
+		return err
+	}
+
+	// the requests are read once and sent again on every call
+	var requests []proto.Message
+	next := requestReader(e, md, args[1:])
+	for {
+		req, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		requests = append(requests, req)
+	}
+	if len(requests) == 0 {
+		return fmt.Errorf("rpc bench needs at least one request")
+	}
+
+	res := runBench(e.ctx, grpcdynamic.NewStub(e.conn), md, requests, *concurrency, *qps, *duration, *timeout)
+
+	if *outFile != "" {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(*outFile, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+	return printBench(e.out, res)
+}
+
+// runBench calls md from concurrency workers until duration is over, paced to
+// qps calls per second when qps is not 0
+func runBench(ctx context.Context, stub grpcdynamic.Stub, md *desc.MethodDescriptor, requests []proto.Message, concurrency int, qps float64, duration, timeout time.Duration) *benchResult {
+	runCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var tokens chan struct{}
+	if qps > 0 {
+		tokens = make(chan struct{})
+		go func() {
+			ticker := time.NewTicker(time.Duration(float64(time.Second) / qps))
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					select {
+					case tokens <- struct{}{}:
+					case <-runCtx.Done():
+						return
+					}
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	workers := make([]*benchWorker, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := range workers {
+		w := &benchWorker{codes: make(map[string]int)}
+		workers[i] = w
+		wg.Add(1)
+		go func(offset int) {
+			defer wg.Done()
+			for n := offset; ; n += concurrency {
+				if tokens != nil {
+					select {
+					case <-tokens:
+					case <-runCtx.Done():
+						return
+					}
+				}
+				if runCtx.Err() != nil {
+					return
+				}
+				w.call(runCtx, stub, md, callRequests(md, requests, n), timeout)
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	res := &benchResult{
+		Method:      md.GetService().GetFullyQualifiedName() + "/" + md.GetName(),
+		Concurrency: concurrency,
+		TargetQPS:   qps,
+		Duration:    elapsed.Seconds(),
+		StatusCodes: make(map[string]int),
+	}
+	var latencies []time.Duration
+	for _, w := range workers {
+		latencies = append(latencies, w.latencies...)
+		res.Responses += w.responses
+		for code, count := range w.codes {
+			res.StatusCodes[code] += count
+		}
+	}
+	res.Calls = len(latencies)
+	res.Errors = res.Calls - res.StatusCodes["OK"]
+	res.Throughput = float64(res.Calls) / elapsed.Seconds()
+	res.Latency = summarizeLatencies(latencies)
+	return res
+}
+
+// callRequests picks the requests of the nth call, unary and server streaming
+// methods take turns over the requests while client streams send all of them
+func callRequests(md *desc.MethodDescriptor, requests []proto.Message, n int) []proto.Message {
+	if md.IsClientStreaming() {
+		return requests
+	}
+	return requests[n%len(requests) : n%len(requests)+1]
+}
+
+// call records one call of md, a call cut by the end of the run, when
+// runCtx is done, is not recorded
+func (w *benchWorker) call(runCtx context.Context, stub grpcdynamic.Stub, md *desc.MethodDescriptor, requests []proto.Message, timeout time.Duration) {
+	ctx := runCtx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(runCtx, timeout)
+		defer cancel()
+	}
+	i := 0
+	next := func() (proto.Message, error) {
+		if i == len(requests) {
+			return nil, io.EOF
+		}
+		i++
+		return requests[i-1], nil
+	}
+	start := time.Now()
+	err := invokeMethod(ctx, stub, md, next, func(proto.Message) error {
+		w.responses++
+		return nil
+	})
+	if err != nil && runCtx.Err() != nil {
+		return
+	}
+	w.latencies = append(w.latencies, time.Since(start))
+	w.codes[status.Code(err).String()]++
+}
+
+// summarizeLatencies returns the nearest rank percentiles of latencies in milliseconds
+func summarizeLatencies(latencies []time.Duration) latencySummary {
+	if len(latencies) == 0 {
+		return latencySummary{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	ms := func(d time.Duration) float64 {
+		return float64(d) / float64(time.Millisecond)
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(latencies))))
+		if rank < 1 {
+			rank = 1
+		}
+		return ms(latencies[rank-1])
+	}
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	return latencySummary{
+		Min:  ms(latencies[0]),
+		Mean: ms(total) / float64(len(latencies)),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		Max:  ms(latencies[len(latencies)-1]),
+	}
+}
+
+func printBench(p *printer, res *benchResult) error {
+	if p.format == "json" {
+		return json.NewEncoder(p.w).Encode(res)
+	}
+	fmt.Fprintf(p.w, "Method:       %v\n", res.Method)
+	fmt.Fprintf(p.w, "Concurrency:  %v\n", res.Concurrency)
+	if res.TargetQPS > 0 {
+		fmt.Fprintf(p.w, "Target QPS:   %v\n", res.TargetQPS)
+	}
+	fmt.Fprintf(p.w, "Calls:        %v in %.2fs, %v errors, %v responses\n", res.Calls, res.Duration, res.Errors, res.Responses)
+	fmt.Fprintf(p.w, "Throughput:   %.2f calls/s\n", res.Throughput)
+	l := res.Latency
+	fmt.Fprintf(p.w, "Latency (ms): min %.3f, mean %.3f, p50 %.3f, p90 %.3f, p95 %.3f, p99 %.3f, max %.3f\n",
+		l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.Max)
+	fmt.Fprintln(p.w, "Status codes:")
+	var codes []string
+	for code := range res.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		fmt.Fprintf(p.w, "  %-20v %v\n", code, res.StatusCodes[code])
+	}
+	return nil
+}
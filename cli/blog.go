@@ -0,0 +1,698 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+var blogCommands = map[string]*command{
+	"create": {
+		usage:       "--author <id> --title <title> [--content <text> | --content - < file] [--tags a,b] [--status draft|published] [--publish-at <time>] [--idempotency-key <key>]",
+		description: "CreateBlog (unary), published unless --status draft or --publish-at, only retried when UNAVAILABLE if it has an idempotency key",
+		run:         blogCreate,
+	},
+	"read": {
+		usage:       "<id> [--fields title,content]",
+		description: "ReadBlog (unary), only the fields given if any",
+		run:         blogRead,
+	},
+	"update": {
+		usage:       "<id> [--author <id>] [--title <title>] [--content <text> | --content - < file] [--tags a,b] [--editor <name>] [--etag <etag>]",
+		description: "UpdateBlog (unary), changes the fields given and records a revision",
+		run:         blogUpdate,
+	},
+	"delete": {
+		usage:       "<id> [--etag <etag>] [--editor <name>]",
+		description: "DeleteBlog (unary), moves the blog to the trash",
+		run:         blogDelete,
+	},
+	"trash": {
+		usage:       "[--author <id>] [--page-size n] [--page-token <token>] [--all]",
+		description: "ListDeletedBlogs (unary), the last deleted first, one page unless --all",
+		run:         blogTrash,
+	},
+	"undelete": {
+		usage:       "<id> [--etag <etag>] [--editor <name>]",
+		description: "RestoreBlog (unary), takes the blog out of the trash",
+		run:         blogUndelete,
+	},
+	"publish": {
+		usage:       "<id> [--etag <etag>] [--editor <name>]",
+		description: "PublishBlog (unary), from DRAFT or SCHEDULED",
+		run:         blogPublish,
+	},
+	"unpublish": {
+		usage:       "<id> [--etag <etag>] [--editor <name>]",
+		description: "UnpublishBlog (unary), back to DRAFT",
+		run:         blogUnpublish,
+	},
+	"schedule": {
+		usage:       "<id> <time> [--etag <etag>] [--editor <name>]",
+		description: "ScheduleBlog (unary), published by the server at the RFC 3339 time",
+		run:         blogSchedule,
+	},
+	"archive": {
+		usage:       "<id> [--etag <etag>] [--editor <name>]",
+		description: "ArchiveBlog (unary), from PUBLISHED",
+		run:         blogArchive,
+	},
+	"revisions": {
+		usage:       "<id> [--page-size n] [--page-token <token>] [--all]",
+		description: "ListBlogRevisions (unary), newest first, one page unless --all",
+		run:         blogRevisions,
+	},
+	"revision": {
+		usage:       "<id> <number>",
+		description: "GetBlogRevision (unary)",
+		run:         blogRevision,
+	},
+	"restore": {
+		usage:       "<id> <number> [--editor <name>] [--etag <etag>]",
+		description: "RestoreBlogRevision (unary), makes an old revision current",
+		run:         blogRestore,
+	},
+	"diff": {
+		usage:       "<id> <from number> <to number>",
+		description: "DiffBlogRevisions (unary), prints unified diffs of the title and content",
+		run:         blogDiff,
+	},
+	"list": {
+		usage:       "[--author <id>] [--tag <tag>] [--status <status>] [--since <time>] [--until <time>] [--oldest-first] [--page-size n] [--page-token <token>] [--all]",
+		description: "ListBlogs (unary), the published blogs unless --status, newest first, one page unless --all",
+		run:         blogList,
+	},
+	"search": {
+		usage:       "<query> [--limit n]",
+		description: "SearchBlogs (server streaming), most relevant first",
+		run:         blogSearch,
+	},
+	"watch": {
+		usage:       "[--author id] [--resume-token token]",
+		description: "WatchBlogs (server streaming), the changes from now on or after the token, until interrupted",
+		run:         blogWatch,
+	},
+	"import": {
+		usage:       "[--format jsonl|markdown|atom] [--in path] [--dry-run] [--upsert]",
+		description: "ImportBlogs (client streaming), the external_id of a blog, or else its id, is its external ID",
+		run:         blogImport,
+	},
+	"export": {
+		usage:       "[--format jsonl|markdown|atom|rss] [--out path] [--author id] [--tag t] [--status s] [--since t] [--until t] [--title t] [--link url]",
+		description: "ExportBlogs (server streaming), a JSON blog per line, a Markdown file per blog or a feed",
+		run:         blogExport,
+	},
+}
+
+func blogCreate(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog create", flag.ExitOnError)
+	author := fs.String("author", "", "author id")
+	title := fs.String("title", "", "title")
+	content := fs.String("content", "", "content, read from stdin when -")
+	tags := fs.String("tags", "", "comma separated tags")
+	status := fs.String("status", "published", "draft or published, the blog is only listed and searched once published")
+	publishAt := fs.String("publish-at", "", "RFC 3339 time the server publishes the blog at, it is scheduled until then")
+	idempotencyKey := fs.String("idempotency-key", "", "sending the same key again returns the blog created the first time")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *content == "-" {
+		data, err := ioutil.ReadAll(e.in)
+		if err != nil {
+			return err
+		}
+		*content = string(data)
+	}
+	blog := &blogpb.Blog{
+		AuthorId: *author,
+		Title:    *title,
+		Content:  *content,
+		Tags:     splitList(*tags),
+	}
+	switch strings.ToLower(*status) {
+	case "published":
+		blog.Status = blogpb.Blog_PUBLISHED
+	case "draft":
+		blog.Status = blogpb.Blog_DRAFT
+	default:
+		return fmt.Errorf("unknown status %q, use draft or published", *status)
+	}
+	if *publishAt != "" {
+		var err error
+		if blog.PublishAt, err = parseTimestamp(*publishAt); err != nil {
+			return fmt.Errorf("--publish-at: %v", err)
+		}
+		blog.Status = blogpb.Blog_SCHEDULED
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.CreateBlog(e.ctx, &blogpb.CreateBlogRequest{
+		Blog:           blog,
+		IdempotencyKey: *idempotencyKey,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+func blogRead(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog read", flag.ExitOnError)
+	fields := fs.String("fields", "", "comma separated blog fields to return, all of them when empty")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("blog read needs a blog id")
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.ReadBlog(e.ctx, &blogpb.ReadBlogRequest{
+		BlogId:   args[0],
+		ReadMask: &field_mask.FieldMask{Paths: splitList(*fields)},
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+func blogUpdate(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog update", flag.ExitOnError)
+	author := fs.String("author", "", "author id")
+	title := fs.String("title", "", "title")
+	content := fs.String("content", "", "content, read from stdin when -")
+	tags := fs.String("tags", "", "comma separated tags")
+	editor := fs.String("editor", "", "who made the change, the author when empty")
+	etag := fs.String("etag", "", "etag of the blog when it was read, the update fails with ABORTED if it changed since")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("blog update needs a blog id")
+	}
+	if *content == "-" {
+		data, err := ioutil.ReadAll(e.in)
+		if err != nil {
+			return err
+		}
+		*content = string(data)
+	}
+	// only the fields given are changed
+	mask := &field_mask.FieldMask{}
+	fs.Visit(func(f *flag.Flag) {
+		if path, ok := blogFlagPaths[f.Name]; ok {
+			mask.Paths = append(mask.Paths, path)
+		}
+	})
+	if len(mask.Paths) == 0 {
+		return fmt.Errorf("blog update needs a field to change")
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.UpdateBlog(e.ctx, &blogpb.UpdateBlogRequest{
+		Blog: &blogpb.Blog{
+			Id:       args[0],
+			AuthorId: *author,
+			Title:    *title,
+			Content:  *content,
+			Tags:     splitList(*tags),
+			Etag:     *etag,
+		},
+		Editor:     *editor,
+		UpdateMask: mask,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+// blogFlagPaths are the blog fields set by the flags of blog update
+var blogFlagPaths = map[string]string{
+	"author":  "author_id",
+	"title":   "title",
+	"content": "content",
+	"tags":    "tags",
+}
+
+func blogDelete(e *env, args []string) error {
+	id, _, etag, editor, err := statusFlags("delete", args, 0)
+	if err != nil {
+		return err
+	}
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.DeleteBlog(e.ctx, &blogpb.DeleteBlogRequest{BlogId: id, Etag: etag, Editor: editor})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func blogTrash(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog trash", flag.ExitOnError)
+	author := fs.String("author", "", "only the blogs of this author")
+	pageSize := fs.Int("page-size", 0, "blogs of a page, the server default when 0")
+	pageToken := fs.String("page-token", "", "next_page_token of the previous page")
+	all := fs.Bool("all", false, "follow next_page_token until the last page")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	req := &blogpb.ListDeletedBlogsRequest{
+		AuthorId:  *author,
+		PageSize:  int32(*pageSize),
+		PageToken: *pageToken,
+	}
+	for {
+		res, err := c.ListDeletedBlogs(e.ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+		if !*all || res.GetNextPageToken() == "" {
+			return nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+func blogUndelete(e *env, args []string) error {
+	id, _, etag, editor, err := statusFlags("undelete", args, 0)
+	if err != nil {
+		return err
+	}
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.RestoreBlog(e.ctx, &blogpb.RestoreBlogRequest{BlogId: id, Etag: etag, Editor: editor})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+// statusFlags parses the arguments of the status changes and of the trash,
+// the blog id and the extra arguments
+func statusFlags(name string, args []string, extra int) (id string, rest []string, etag, editor string, err error) {
+	fs := flag.NewFlagSet("blog "+name, flag.ExitOnError)
+	etagFlag := fs.String("etag", "", "etag of the blog when it was read, the change fails with ABORTED if it changed since")
+	editorFlag := fs.String("editor", "", "who made the change, the author when empty")
+	args, err = parseFlags(fs, args)
+	if err != nil {
+		return "", nil, "", "", err
+	}
+	if len(args) != 1+extra {
+		return "", nil, "", "", fmt.Errorf("blog %v needs %v arguments", name, 1+extra)
+	}
+	return args[0], args[1:], *etagFlag, *editorFlag, nil
+}
+
+func blogPublish(e *env, args []string) error {
+	id, _, etag, editor, err := statusFlags("publish", args, 0)
+	if err != nil {
+		return err
+	}
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.PublishBlog(e.ctx, &blogpb.PublishBlogRequest{BlogId: id, Etag: etag, Editor: editor})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+func blogUnpublish(e *env, args []string) error {
+	id, _, etag, editor, err := statusFlags("unpublish", args, 0)
+	if err != nil {
+		return err
+	}
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.UnpublishBlog(e.ctx, &blogpb.UnpublishBlogRequest{BlogId: id, Etag: etag, Editor: editor})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+func blogSchedule(e *env, args []string) error {
+	id, rest, etag, editor, err := statusFlags("schedule", args, 1)
+	if err != nil {
+		return err
+	}
+	publishAt, err := parseTimestamp(rest[0])
+	if err != nil {
+		return err
+	}
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.ScheduleBlog(e.ctx, &blogpb.ScheduleBlogRequest{BlogId: id, PublishAt: publishAt, Etag: etag, Editor: editor})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+func blogArchive(e *env, args []string) error {
+	id, _, etag, editor, err := statusFlags("archive", args, 0)
+	if err != nil {
+		return err
+	}
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.ArchiveBlog(e.ctx, &blogpb.ArchiveBlogRequest{BlogId: id, Etag: etag, Editor: editor})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetBlog())
+}
+
+func blogRevisions(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog revisions", flag.ExitOnError)
+	pageSize := fs.Int("page-size", 0, "revisions of a page, the server default when 0")
+	pageToken := fs.String("page-token", "", "next_page_token of the previous page")
+	all := fs.Bool("all", false, "follow next_page_token until the last page")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("blog revisions needs a blog id")
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	req := &blogpb.ListBlogRevisionsRequest{
+		BlogId:    args[0],
+		PageSize:  int32(*pageSize),
+		PageToken: *pageToken,
+	}
+	for {
+		res, err := c.ListBlogRevisions(e.ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+		if !*all || res.GetNextPageToken() == "" {
+			return nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+func blogRevision(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog revision", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return fmt.Errorf("blog revision needs a blog id and a revision number")
+	}
+	number, err := parseRevision(args[1])
+	if err != nil {
+		return err
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.GetBlogRevision(e.ctx, &blogpb.GetBlogRevisionRequest{BlogId: args[0], Number: number})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res.GetRevision())
+}
+
+func blogRestore(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog restore", flag.ExitOnError)
+	editor := fs.String("editor", "", "who made the change, the author when empty")
+	etag := fs.String("etag", "", "etag of the blog when it was read, the restore fails with ABORTED if it changed since")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 2 {
+		return fmt.Errorf("blog restore needs a blog id and a revision number")
+	}
+	number, err := parseRevision(args[1])
+	if err != nil {
+		return err
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.RestoreBlogRevision(e.ctx, &blogpb.RestoreBlogRevisionRequest{
+		BlogId: args[0],
+		Number: number,
+		Editor: *editor,
+		Etag:   *etag,
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func blogDiff(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog diff", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 3 {
+		return fmt.Errorf("blog diff needs a blog id and two revision numbers")
+	}
+	from, err := parseRevision(args[1])
+	if err != nil {
+		return err
+	}
+	to, err := parseRevision(args[2])
+	if err != nil {
+		return err
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	res, err := c.DiffBlogRevisions(e.ctx, &blogpb.DiffBlogRevisionsRequest{
+		BlogId:     args[0],
+		FromNumber: from,
+		ToNumber:   to,
+	})
+	if err != nil {
+		return err
+	}
+	if e.out.format == "json" {
+		return e.out.print(res)
+	}
+	// diffs are only readable as they are
+	_, err = fmt.Fprint(e.out.w, res.GetTitleDiff(), res.GetContentDiff())
+	return err
+}
+
+func parseRevision(s string) (int64, error) {
+	number, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read revision number %q", s)
+	}
+	return number, nil
+}
+
+func blogList(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog list", flag.ExitOnError)
+	author := fs.String("author", "", "only the blogs of this author")
+	tag := fs.String("tag", "", "only the blogs with this tag")
+	status := fs.String("status", "", "only the blogs with this status: draft, scheduled, published or archived")
+	since := fs.String("since", "", "only the blogs created at or after this time, RFC 3339 or YYYY-MM-DD")
+	until := fs.String("until", "", "only the blogs created before this time, RFC 3339 or YYYY-MM-DD")
+	oldestFirst := fs.Bool("oldest-first", false, "list the oldest blogs first")
+	pageSize := fs.Int("page-size", 0, "blogs of a page, the server default when 0")
+	pageToken := fs.String("page-token", "", "next_page_token of the previous page")
+	all := fs.Bool("all", false, "follow next_page_token until the last page")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	req := &blogpb.ListBlogsRequest{
+		AuthorId:  *author,
+		Tag:       *tag,
+		PageSize:  int32(*pageSize),
+		PageToken: *pageToken,
+	}
+	if *oldestFirst {
+		req.Order = blogpb.ListBlogsRequest_OLDEST_FIRST
+	}
+	if *status != "" {
+		value, ok := blogpb.Blog_Status_value[strings.ToUpper(*status)]
+		if !ok {
+			return fmt.Errorf("unknown status %q, use draft, scheduled, published or archived", *status)
+		}
+		req.Status = blogpb.Blog_Status(value)
+	}
+	var err error
+	if req.CreatedAfter, err = parseTimestamp(*since); err != nil {
+		return fmt.Errorf("--since: %v", err)
+	}
+	if req.CreatedBefore, err = parseTimestamp(*until); err != nil {
+		return fmt.Errorf("--until: %v", err)
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	for {
+		res, err := c.ListBlogs(e.ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+		if !*all || res.GetNextPageToken() == "" {
+			return nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+// parseTimestamp reads an RFC 3339 time or a date, nil when s is empty
+func parseTimestamp(s string) (*timestamp.Timestamp, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", s); err != nil {
+			return nil, fmt.Errorf("%q is neither an RFC 3339 time nor a YYYY-MM-DD date", s)
+		}
+	}
+	return ptypes.TimestampProto(t)
+}
+
+// splitList splits a comma separated list, nil when s is empty
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func blogSearch(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog search", flag.ExitOnError)
+	limit := fs.Int("limit", 0, "blogs to receive, the server default when 0")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("blog search needs a query")
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	stream, err := c.SearchBlogs(e.ctx, &blogpb.SearchBlogsRequest{
+		Query: strings.Join(args, " "),
+		Limit: int32(*limit),
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+}
+
+func blogWatch(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog watch", flag.ExitOnError)
+	author := fs.String("author", "", "only the changes of the blogs of this author")
+	resumeToken := fs.String("resume-token", "", "resume_token of the last change received")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	stream, err := c.WatchBlogs(e.ctx, &blogpb.WatchBlogsRequest{
+		ResumeToken: *resumeToken,
+		AuthorId:    *author,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+}
+
+func blogImport(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog import", flag.ExitOnError)
+	format := fs.String("format", "jsonl", "jsonl, markdown or atom, as written by blog export")
+	in := fs.String("in", "", "file to read, stdin when empty, or the directory of the Markdown files")
+	dryRun := fs.Bool("dry-run", false, "only tell what would be done")
+	upsert := fs.Bool("upsert", false, "update the blogs imported before with the same external ID")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	next, err := blogReader(*format, *in, e.in)
+	if err != nil {
+		return err
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	stream, err := c.ImportBlogs(e.ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		blog, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// a blog exported by another server keeps its ID
+		externalID := blog.GetExternalId()
+		if externalID == "" {
+			externalID = blog.GetId()
+		}
+		err = stream.Send(&blogpb.ImportBlogsRequest{
+			Blog:       blog,
+			ExternalId: externalID,
+			DryRun:     *dryRun,
+			Upsert:     *upsert,
+		})
+		if err == io.EOF {
+			// the server failed the stream, CloseAndRecv returns why
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/calculator/calculatorpb"
+)
+
+var calcCommands = map[string]*command{
+	"sum": {
+		usage:       "<a> <b>",
+		description: "Sum (unary)",
+		run:         calcSum,
+	},
+	"primes": {
+		usage:       "<n>",
+		description: "PrimeNumberDecomposition (server streaming)",
+		run:         calcPrimes,
+	},
+	"average": {
+		usage:       "< numbers",
+		description: "ComputeAverage (client streaming) of the numbers read from stdin",
+		run:         calcAverage,
+	},
+	"stats": {
+		usage:       "[--percentiles 90,99] < numbers",
+		description: "ComputeStatistics (client streaming) of the numbers read from stdin",
+		run:         calcStats,
+	},
+	"max": {
+		usage:       "< numbers",
+		description: "FindMaximum (bidi streaming) of the integers read from stdin",
+		run:         calcMax,
+	},
+	"rolling": {
+		usage:       "--aggregate max|min|sum|mean (--size n | --duration d [--slide d]) [--sliding] < numbers",
+		description: "RollingAggregate (bidi streaming) of the numbers read from stdin",
+		run:         calcRolling,
+	},
+	"sqrt": {
+		usage:       "<n>",
+		description: "SquareRoot (unary), fails with INVALID_ARGUMENT for negative numbers",
+		run:         calcSqrt,
+	},
+}
+
+func calcSum(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc sum", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	numbers, err := parseFloats(args)
+	if err != nil {
+		return err
+	}
+	if len(numbers) != 2 {
+		return fmt.Errorf("calc sum needs two numbers")
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	res, err := c.Sum(e.ctx, &calculatorpb.SumRequest{
+		Sum: &calculatorpb.Sum{
+			FirstNumber:  float32(numbers[0]),
+			SecondNumber: float32(numbers[1]),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func calcPrimes(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc primes", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("calc primes needs a number")
+	}
+	number, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("cannot read number %q", args[0])
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	stream, err := c.PrimeNumberDecomposition(e.ctx, &calculatorpb.PrimeNumberDecompositionRequest{
+		PrimeDecompositon: &calculatorpb.PrimeDecompositon{
+			Number: number,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+}
+
+func calcAverage(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc average", flag.ExitOnError)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	stream, err := c.ComputeAverage(e.ctx)
+	if err != nil {
+		return err
+	}
+	err = readNumbers(e.in, func(n float64) error {
+		return stream.Send(&calculatorpb.ComputeAverageRequest{
+			AverageComposition: &calculatorpb.AverageComposition{
+				Number: n,
+			},
+		})
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func calcStats(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc stats", flag.ExitOnError)
+	percentilesFlag := fs.String("percentiles", "", "comma separated percentiles, the server default when empty")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	var percentiles []float64
+	if *percentilesFlag != "" {
+		var err error
+		if percentiles, err = parseFloats(strings.Split(*percentilesFlag, ",")); err != nil {
+			return err
+		}
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	stream, err := c.ComputeStatistics(e.ctx)
+	if err != nil {
+		return err
+	}
+	first := true
+	err = readNumbers(e.in, func(n float64) error {
+		req := &calculatorpb.ComputeStatisticsRequest{Number: n}
+		if first {
+			// the percentiles are only read from the first message
+			req.Percentiles = percentiles
+			first = false
+		}
+		return stream.Send(req)
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func calcMax(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc max", flag.ExitOnError)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	stream, err := c.FindMaximum(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		err := readNumbers(e.in, func(n float64) error {
+			return stream.Send(&calculatorpb.FindMaximumRequest{Number: int32(n)})
+		})
+		stream.CloseSend()
+		sendErr <- err
+	}()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+	if err := <-sendErr; err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func calcRolling(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc rolling", flag.ExitOnError)
+	aggregateFlag := fs.String("aggregate", "max", "max, min, sum or mean")
+	size := fs.Int("size", 0, "count based window size")
+	duration := fs.Duration("duration", 0, "time based window duration")
+	slide := fs.Duration("slide", 0, "how often a time based sliding window is emitted")
+	sliding := fs.Bool("sliding", false, "sliding window instead of tumbling")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	aggregate, ok := calculatorpb.Aggregate_value[strings.ToUpper(*aggregateFlag)]
+	if !ok {
+		return fmt.Errorf("unknown aggregate %q", *aggregateFlag)
+	}
+	window := &calculatorpb.Window{
+		Size:       int32(*size),
+		DurationMs: int64(*duration / time.Millisecond),
+		SlideMs:    int64(*slide / time.Millisecond),
+	}
+	if *sliding {
+		window.Kind = calculatorpb.Window_SLIDING
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	stream, err := c.RollingAggregate(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		first := true
+		err := readNumbers(e.in, func(n float64) error {
+			req := &calculatorpb.RollingAggregateRequest{Number: n}
+			if first {
+				// the aggregate and the window are only read from the first message
+				req.Aggregate = calculatorpb.Aggregate(aggregate)
+				req.Window = window
+				first = false
+			}
+			return stream.Send(req)
+		})
+		stream.CloseSend()
+		sendErr <- err
+	}()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+	if err := <-sendErr; err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func calcSqrt(e *env, args []string) error {
+	fs := flag.NewFlagSet("calc sqrt", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("calc sqrt needs a number")
+	}
+	number, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("cannot read number %q", args[0])
+	}
+
+	c := calculatorpb.NewCalculatorServiceClient(e.conn)
+	res, err := c.SquareRoot(e.ctx, &calculatorpb.SquareRootRequest{Number: int32(number)})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/FernandoDevBh/grpc-go-course/clientconn"
+	"google.golang.org/grpc"
+)
+
+// dial connects to the server configured by the global flags
+func dial() (*grpc.ClientConn, error) {
+	c := clientconn.Config{
+		TLS:        *tls,
+		CAFile:     *caFile,
+		ServerName: *serverName,
+		Retry:      clientconn.DefaultRetryPolicy,
+	}
+	c.Retry.MaxAttempts = *attempts
+	if *hedgeDelay > 0 {
+		c.Hedging = &clientconn.HedgingPolicy{MaxAttempts: *attempts, Delay: *hedgeDelay}
+	}
+	return clientconn.Dial(*address, c)
+}
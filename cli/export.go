@@ -0,0 +1,494 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// the keys of the front matter of a Markdown blog, in order. The values are
+// JSON, which YAML reads too.
+var frontMatterKeys = []string{
+	"id", "external_id", "author_id", "title", "tags", "status",
+	"created_at", "updated_at", "publish_at", "published_at",
+}
+
+// the Atom IDs of the entries are the blog IDs with this prefix
+const atomIDPrefix = "urn:blog:"
+
+func blogExport(e *env, args []string) error {
+	fs := flag.NewFlagSet("blog export", flag.ExitOnError)
+	format := fs.String("format", "jsonl", "jsonl, markdown, atom or rss")
+	out := fs.String("out", "", "file to write, stdout when empty, or the directory of the Markdown files")
+	author := fs.String("author", "", "only the blogs of this author")
+	tag := fs.String("tag", "", "only the blogs with this tag")
+	status := fs.String("status", "", "only the blogs with this status, any for jsonl and markdown and published for the feeds when empty")
+	since := fs.String("since", "", "only the blogs created at or after this time, RFC 3339 or YYYY-MM-DD")
+	until := fs.String("until", "", "only the blogs created before this time, RFC 3339 or YYYY-MM-DD")
+	title := fs.String("title", "Blogs", "title of the feed")
+	link := fs.String("link", "", "URL of the site of the feed, the blogs are at <link>/<id>")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	req := &blogpb.ExportBlogsRequest{
+		AuthorId: *author,
+		Tag:      *tag,
+	}
+	if *status == "" && (*format == "atom" || *format == "rss") {
+		*status = "published"
+	}
+	if *status != "" {
+		value, ok := blogpb.Blog_Status_value[strings.ToUpper(*status)]
+		if !ok {
+			return fmt.Errorf("unknown status %q, use draft, scheduled, published or archived", *status)
+		}
+		req.Status = blogpb.Blog_Status(value)
+	}
+	var err error
+	if req.CreatedAfter, err = parseTimestamp(*since); err != nil {
+		return fmt.Errorf("--since: %v", err)
+	}
+	if req.CreatedBefore, err = parseTimestamp(*until); err != nil {
+		return fmt.Errorf("--until: %v", err)
+	}
+
+	var w blogWriter
+	switch *format {
+	case "markdown":
+		if *out == "" {
+			return fmt.Errorf("blog export --format markdown needs an --out directory")
+		}
+		if err := os.MkdirAll(*out, 0755); err != nil {
+			return err
+		}
+		w = &markdownWriter{dir: *out}
+	case "jsonl", "atom", "rss":
+		output := e.out.w
+		if *out != "" {
+			f, err := os.Create(*out)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			output = f
+		}
+		if *format == "jsonl" {
+			w = &jsonlWriter{w: output}
+		} else {
+			w = &feedWriter{format: *format, title: *title, link: strings.TrimSuffix(*link, "/"), w: output}
+		}
+	default:
+		return fmt.Errorf("unknown format %q, use jsonl, markdown, atom or rss", *format)
+	}
+
+	c := blogpb.NewBlogServiceClient(e.conn)
+	stream, err := c.ExportBlogs(e.ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return w.close()
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.write(res.GetBlog()); err != nil {
+			return err
+		}
+	}
+}
+
+// blogWriter writes the exported blogs in a format
+type blogWriter interface {
+	write(blog *blogpb.Blog) error
+	// close ends the export
+	close() error
+}
+
+// jsonlWriter writes a JSON blog per line
+type jsonlWriter struct {
+	w io.Writer
+}
+
+func (j *jsonlWriter) write(blog *blogpb.Blog) error {
+	line, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(blog)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(j.w, line)
+	return err
+}
+
+func (j *jsonlWriter) close() error {
+	return nil
+}
+
+// markdownWriter writes every blog to <id>.md in dir, its content after a
+// front matter with the other fields
+type markdownWriter struct {
+	dir string
+}
+
+func (m *markdownWriter) write(blog *blogpb.Blog) error {
+	content := blog.GetContent()
+	withoutContent := *blog
+	withoutContent.Content = ""
+	data, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(&withoutContent)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	for _, key := range frontMatterKeys {
+		if value, ok := fields[key]; ok {
+			fmt.Fprintf(&b, "%v: %s\n", key, value)
+		}
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(content)
+	return ioutil.WriteFile(filepath.Join(m.dir, blog.GetId()+".md"), b.Bytes(), 0644)
+}
+
+func (m *markdownWriter) close() error {
+	return nil
+}
+
+// parseMarkdown reads a blog written by markdownWriter, the unknown keys of
+// the front matter are ignored
+func parseMarkdown(data []byte) (*blogpb.Blog, error) {
+	text := strings.Replace(string(data), "\r\n", "\n", -1)
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, fmt.Errorf("no front matter")
+	}
+	end := strings.Index(text[4:], "\n---\n")
+	if end < 0 {
+		return nil, fmt.Errorf("the front matter does not end with ---")
+	}
+	frontMatter, content := text[4:4+end], text[4+end+5:]
+
+	fields := make(map[string]json.RawMessage)
+	for i, line := range strings.Split(frontMatter, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		value := json.RawMessage(strings.TrimSpace(parts[len(parts)-1]))
+		if len(parts) != 2 || !json.Valid(value) {
+			return nil, fmt.Errorf("line %v of the front matter is not a key and a JSON value", i+2)
+		}
+		fields[strings.TrimSpace(parts[0])] = value
+	}
+	fields["content"], _ = json.Marshal(strings.TrimPrefix(content, "\n"))
+	data, _ = json.Marshal(fields)
+
+	blog := &blogpb.Blog{}
+	if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(bytes.NewReader(data), blog); err != nil {
+		return nil, err
+	}
+	return blog, nil
+}
+
+// feedWriter writes the blogs as an Atom or RSS 2.0 feed, the newest first
+type feedWriter struct {
+	format string
+	title  string
+	link   string
+	w      io.Writer
+	blogs  []*blogpb.Blog
+}
+
+func (f *feedWriter) write(blog *blogpb.Blog) error {
+	f.blogs = append(f.blogs, blog)
+	return nil
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    *atomLink   `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Author     atomAuthor     `xml:"author"`
+	Link       *atomLink      `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	ID          string `xml:",chardata"`
+}
+
+func (f *feedWriter) close() error {
+	sort.SliceStable(f.blogs, func(i, j int) bool {
+		return feedTime(f.blogs[i]).After(feedTime(f.blogs[j]))
+	})
+	updated := time.Now()
+	if len(f.blogs) > 0 {
+		updated = toTime(f.blogs[0].GetUpdatedAt())
+	}
+	for _, blog := range f.blogs {
+		if t := toTime(blog.GetUpdatedAt()); t.After(updated) {
+			updated = t
+		}
+	}
+
+	var feed interface{}
+	if f.format == "atom" {
+		atom := &atomFeed{
+			Title:   f.title,
+			ID:      atomIDPrefix + "feed",
+			Updated: updated.UTC().Format(time.RFC3339),
+		}
+		if f.link != "" {
+			atom.ID = f.link
+			atom.Link = &atomLink{Href: f.link}
+		}
+		for _, blog := range f.blogs {
+			entry := atomEntry{
+				ID:        atomIDPrefix + blog.GetId(),
+				Title:     blog.GetTitle(),
+				Updated:   toTime(blog.GetUpdatedAt()).UTC().Format(time.RFC3339),
+				Published: feedTime(blog).UTC().Format(time.RFC3339),
+				Author:    atomAuthor{Name: blog.GetAuthorId()},
+				Content:   atomContent{Type: "text", Text: blog.GetContent()},
+			}
+			if f.link != "" {
+				entry.Link = &atomLink{Href: f.link + "/" + blog.GetId()}
+			}
+			for _, tag := range blog.GetTags() {
+				entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+			}
+			atom.Entries = append(atom.Entries, entry)
+		}
+		feed = atom
+	} else {
+		rss := &rssFeed{
+			Version: "2.0",
+			Channel: rssChannel{
+				Title:         f.title,
+				Link:          f.link,
+				Description:   f.title,
+				LastBuildDate: updated.UTC().Format(time.RFC1123Z),
+			},
+		}
+		for _, blog := range f.blogs {
+			item := rssItem{
+				Title:       blog.GetTitle(),
+				GUID:        rssGUID{ID: blog.GetId()},
+				PubDate:     feedTime(blog).UTC().Format(time.RFC1123Z),
+				Categories:  blog.GetTags(),
+				Description: blog.GetContent(),
+			}
+			if f.link != "" {
+				item.Link = f.link + "/" + blog.GetId()
+			}
+			rss.Channel.Items = append(rss.Channel.Items, item)
+		}
+		feed = rss
+	}
+
+	if _, err := io.WriteString(f.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(f.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(f.w)
+	return err
+}
+
+// feedTime is when blog was published, or created when it never was
+func feedTime(blog *blogpb.Blog) time.Time {
+	if blog.GetPublishedAt() != nil {
+		return toTime(blog.GetPublishedAt())
+	}
+	return toTime(blog.GetCreatedAt())
+}
+
+// toTime converts ts, the zero time when it is nil or invalid
+func toTime(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseAtom reads the blogs of an Atom feed written by feedWriter, they are
+// published
+func parseAtom(r io.Reader) ([]*blogpb.Blog, error) {
+	var feed atomFeed
+	if err := xml.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, err
+	}
+	var blogs []*blogpb.Blog
+	for _, entry := range feed.Entries {
+		blog := &blogpb.Blog{
+			Id:       strings.TrimPrefix(entry.ID, atomIDPrefix),
+			AuthorId: entry.Author.Name,
+			Title:    entry.Title,
+			Content:  entry.Content.Text,
+			Status:   blogpb.Blog_PUBLISHED,
+		}
+		for _, category := range entry.Categories {
+			blog.Tags = append(blog.Tags, category.Term)
+		}
+		var err error
+		if entry.Published != "" {
+			if blog.PublishedAt, err = parseTimestamp(entry.Published); err != nil {
+				return nil, fmt.Errorf("entry %v: %v", entry.ID, err)
+			}
+			blog.CreatedAt = blog.PublishedAt
+		}
+		if blog.UpdatedAt, err = parseTimestamp(entry.Updated); err != nil {
+			return nil, fmt.Errorf("entry %v: %v", entry.ID, err)
+		}
+		blogs = append(blogs, blog)
+	}
+	return blogs, nil
+}
+
+// blogReader returns the blogs to import from path, or from in when path is
+// empty, one after the other and then io.EOF
+func blogReader(format, path string, in io.Reader) (func() (*blogpb.Blog, error), error) {
+	if format == "markdown" {
+		if path == "" {
+			return nil, fmt.Errorf("blog import --format markdown needs an --in directory")
+		}
+		files, err := filepath.Glob(filepath.Join(path, "*.md"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		return func() (*blogpb.Blog, error) {
+			if len(files) == 0 {
+				return nil, io.EOF
+			}
+			file := files[0]
+			files = files[1:]
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			blog, err := parseMarkdown(data)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %v", file, err)
+			}
+			return blog, nil
+		}, nil
+	}
+
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		in = bytes.NewReader(data)
+	}
+	switch format {
+	case "jsonl":
+		decoder := json.NewDecoder(in)
+		return func() (*blogpb.Blog, error) {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return nil, err
+			}
+			blog := &blogpb.Blog{}
+			if err := jsonpb.UnmarshalString(string(raw), blog); err != nil {
+				return nil, fmt.Errorf("invalid blog: %v", err)
+			}
+			return blog, nil
+		}, nil
+	case "atom":
+		blogs, err := parseAtom(in)
+		if err != nil {
+			return nil, err
+		}
+		// the oldest first, like the other formats
+		return func() (*blogpb.Blog, error) {
+			if len(blogs) == 0 {
+				return nil, io.EOF
+			}
+			blog := blogs[len(blogs)-1]
+			blogs = blogs[:len(blogs)-1]
+			return blog, nil
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, use jsonl, markdown or atom", format)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/greet/greetpb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+var greetCommands = map[string]*command{
+	"unary": {
+		usage:       "--first <name> [--last <name>] [--locale <tag>] [--formal]",
+		description: "Greet (unary)",
+		run:         greetUnary,
+	},
+	"many": {
+		usage:       "--first <name> [--count n] [--interval d] [--template t] [--until-cancelled]",
+		description: "GreetManyTimes (server streaming)",
+		run:         greetMany,
+	},
+	"long": {
+		usage:       "[--locale <tag>] < names",
+		description: "LongGreet (client streaming), one \"First Last\" name per line of stdin",
+		run:         greetLong,
+	},
+	"everyone": {
+		usage:       "[--room <room>] [--locale <tag>] < names",
+		description: "GreetEveryone (bidi streaming), joins the room and greets one name per line of stdin",
+		run:         greetEveryone,
+	},
+	"deadline": {
+		usage:       "--first <name> [--last <name>]",
+		description: "GreetWithDeadLine (unary), use -deadline to set the deadline",
+		run:         greetDeadline,
+	},
+	"history": {
+		usage:       "[--name <name>] [--since d] [--until d]",
+		description: "GetGreetingHistory (server streaming), since and until are durations before now",
+		run:         greetHistory,
+	},
+}
+
+// greetingFlags adds the flags of a Greeting to fs
+func greetingFlags(fs *flag.FlagSet) func() *greetpb.Greeting {
+	first := fs.String("first", "", "first name")
+	last := fs.String("last", "", "last name")
+	locale := fs.String("locale", "", "language of the greeting, e.g. pt-BR")
+	formal := fs.Bool("formal", false, "formal greeting")
+	return func() *greetpb.Greeting {
+		return &greetpb.Greeting{
+			FirstName: *first,
+			LastName:  *last,
+			Locale:    *locale,
+			Formal:    *formal,
+		}
+	}
+}
+
+func greetUnary(e *env, args []string) error {
+	fs := flag.NewFlagSet("greet unary", flag.ExitOnError)
+	greeting := greetingFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := greetpb.NewGreetServiceClient(e.conn)
+	res, err := c.Greet(e.ctx, &greetpb.GreetRequest{Greeting: greeting()})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func greetMany(e *env, args []string) error {
+	fs := flag.NewFlagSet("greet many", flag.ExitOnError)
+	greeting := greetingFlags(fs)
+	count := fs.Int("count", 0, "messages to receive, the server default when 0")
+	interval := fs.Duration("interval", 0, "pause between messages, the server default when 0")
+	template := fs.String("template", "", "message template with {greeting}, {first_name}, {last_name} and {number}")
+	untilCancelled := fs.Bool("until-cancelled", false, "stream until interrupted or the deadline")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := greetpb.NewGreetServiceClient(e.conn)
+	stream, err := c.GreetManyTimes(e.ctx, &greetpb.GreetManyTimesRequest{
+		Greeting:       greeting(),
+		Count:          int32(*count),
+		IntervalMs:     int64(*interval / time.Millisecond),
+		Template:       *template,
+		UntilCancelled: *untilCancelled,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+}
+
+func greetLong(e *env, args []string) error {
+	fs := flag.NewFlagSet("greet long", flag.ExitOnError)
+	locale := fs.String("locale", "", "language of the greetings")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := greetpb.NewGreetServiceClient(e.conn)
+	stream, err := c.LongGreet(e.ctx)
+	if err != nil {
+		return err
+	}
+	err = readLines(e.in, func(line string) error {
+		first, last := splitName(line)
+		return stream.Send(&greetpb.LongGreetRequest{
+			Greeting: &greetpb.Greeting{FirstName: first, LastName: last, Locale: *locale},
+		})
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func greetEveryone(e *env, args []string) error {
+	fs := flag.NewFlagSet("greet everyone", flag.ExitOnError)
+	room := fs.String("room", "", "room to join, the server default when empty")
+	locale := fs.String("locale", "", "language of the greetings")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := greetpb.NewGreetServiceClient(e.conn)
+	stream, err := c.GreetEveryone(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		first := true
+		err := readLines(e.in, func(line string) error {
+			firstName, lastName := splitName(line)
+			req := &greetpb.GreetEveryoneRequest{
+				Greeting: &greetpb.Greeting{FirstName: firstName, LastName: lastName, Locale: *locale},
+			}
+			if first {
+				req.Room = *room
+				first = false
+			}
+			return stream.Send(req)
+		})
+		stream.CloseSend()
+		sendErr <- err
+	}()
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res); err != nil {
+			return err
+		}
+	}
+	if err := <-sendErr; err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func greetDeadline(e *env, args []string) error {
+	fs := flag.NewFlagSet("greet deadline", flag.ExitOnError)
+	greeting := greetingFlags(fs)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c := greetpb.NewGreetServiceClient(e.conn)
+	res, err := c.GreetWithDeadLine(e.ctx, &greetpb.GreetWithDeadLineRequest{Greeting: greeting()})
+	if err != nil {
+		return err
+	}
+	return e.out.print(res)
+}
+
+func greetHistory(e *env, args []string) error {
+	fs := flag.NewFlagSet("greet history", flag.ExitOnError)
+	name := fs.String("name", "", "first, last or full name")
+	since := fs.Duration("since", 0, "only greetings newer than this, e.g. 1h")
+	until := fs.Duration("until", 0, "only greetings older than this")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	req := &greetpb.GetGreetingHistoryRequest{Name: *name}
+	var err error
+	if *since > 0 {
+		if req.Since, err = ptypes.TimestampProto(time.Now().Add(-*since)); err != nil {
+			return err
+		}
+	}
+	if *until > 0 {
+		if req.Until, err = ptypes.TimestampProto(time.Now().Add(-*until)); err != nil {
+			return err
+		}
+	}
+
+	c := greetpb.NewGreetServiceClient(e.conn)
+	stream, err := c.GetGreetingHistory(e.ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(res.GetRecord()); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Command cli calls every RPC of the greet, calculator and blog services.
+//
+// Usage:
+//
+//	cli [global flags] <service> <command> [flags] [args]
+//
+// Examples:
+//
+//	cli -tls greet unary --first Fernando
+//	cli calc sqrt -10
+//	echo 1 2 3 4 | cli calc average
+//	cli -output json blog create --author Fernando --title "My first blog" --content "..."
+//	cli blog read 5c8b2b4d3e6f0a1b2c3d4e5f
+//	cli blog list --tag go --since 2019-01-01 --page-size 10
+//	cli blog diff 5c8b2b4d3e6f0a1b2c3d4e5f 1 3
+//	cli blog schedule 5c8b2b4d3e6f0a1b2c3d4e5f 2030-01-01T09:00:00Z
+//	cli -output json blog watch --author Fernando
+//	cli blog export --format markdown --out backup --author Fernando
+//	cli blog import --format markdown --in backup --upsert
+//	cli -hedge 50ms calc sum 3 10
+//	cli rpc list
+//	cli rpc call calculator.CalculatorService/Sum '{"sum": {"first_number": 3, "second_number": 10}}'
+//	cli rpc bench calculator.CalculatorService/Sum '{"sum": {"first_number": 3, "second_number": 10}}' --concurrency 8 --qps 500 --duration 30s --out sum.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/FernandoDevBh/grpc-go-course/clientconn"
+	"google.golang.org/grpc"
+)
+
+// env is what a command needs to run
+type env struct {
+	ctx  context.Context
+	conn *grpc.ClientConn
+	out  *printer
+	in   io.Reader
+}
+
+type command struct {
+	usage       string
+	description string
+	run         func(e *env, args []string) error
+}
+
+var services = map[string]map[string]*command{
+	"greet": greetCommands,
+	"calc":  calcCommands,
+	"blog":  blogCommands,
+	"rpc":   rpcCommands,
+}
+
+var (
+	address    = flag.String("addr", "localhost:50051", "server address")
+	tls        = flag.Bool("tls", false, "use TLS")
+	caFile     = flag.String("ca", "ssl/ca.crt", "certificate authority trust certificate, used with -tls")
+	serverName = flag.String("server-name", "", "override the server name checked against the certificate")
+	deadline   = flag.Duration("deadline", 0, "deadline of the call, none when 0")
+	output     = flag.String("output", "text", "output format: text or json")
+	attempts   = flag.Int("attempts", clientconn.DefaultRetryPolicy.MaxAttempts, "attempts of the methods safe to retry, including the first one, 1 disables retries")
+	hedgeDelay = flag.Duration("hedge", 0, "send the calls safe to retry again after this delay, instead of retrying them when UNAVAILABLE")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+	commands, ok := services[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown service %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(1)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown %v command %q\n\n", flag.Arg(0), flag.Arg(1))
+		usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(*output, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	conn, err := dial()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not connect: %v\n", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	if *deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *deadline)
+		defer cancel()
+	}
+
+	e := &env{ctx: ctx, conn: conn, out: out, in: os.Stdin}
+	if err := cmd.run(e, flag.Args()[2:]); err != nil {
+		printError(err)
+		conn.Close()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %v [global flags] <service> <command> [flags] [args]\n\nGlobal flags:\n", os.Args[0])
+	flag.PrintDefaults()
+
+	var names []string
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "\n%v commands:\n", name)
+		var cmds []string
+		for cmd := range services[name] {
+			cmds = append(cmds, cmd)
+		}
+		sort.Strings(cmds)
+		for _, cmd := range cmds {
+			c := services[name][cmd]
+			fmt.Fprintf(os.Stderr, "  %v %v %v\n", name, cmd, c.usage)
+			fmt.Fprintf(os.Stderr, "      %v\n", c.description)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bufio"
+	"encoding"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// printer writes the responses in the format picked with -output
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	if format != "text" && format != "json" {
+		return nil, fmt.Errorf("unknown output format %q, use text or json", format)
+	}
+	return &printer{format: format, w: w}, nil
+}
+
+// print writes a message on a single line
+func (p *printer) print(msg proto.Message) error {
+	if p.format == "json" {
+		line, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(msg)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, line)
+		return err
+	}
+	// dynamic messages know how to print themselves
+	if m, ok := msg.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.w, string(text))
+		return err
+	}
+	_, err := fmt.Fprintln(p.w, proto.CompactTextString(msg))
+	return err
+}
+
+// printError shows the status of a failed call with the google.rpc error details attached by the server
+func printError(err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error %v: %v\n", st.Code(), st.Message())
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				fmt.Fprintf(os.Stderr, "  Invalid field %v: %v\n", violation.GetField(), violation.GetDescription())
+			}
+		case *errdetails.ErrorInfo:
+			fmt.Fprintf(os.Stderr, "  Error reason: %v (domain: %v, metadata: %v)\n", d.GetReason(), d.GetDomain(), d.GetMetadata())
+		case *errdetails.PreconditionFailure:
+			for _, violation := range d.GetViolations() {
+				fmt.Fprintf(os.Stderr, "  Precondition %v failed for %v: %v\n", violation.GetType(), violation.GetSubject(), violation.GetDescription())
+			}
+		case *errdetails.QuotaFailure:
+			for _, violation := range d.GetViolations() {
+				fmt.Fprintf(os.Stderr, "  Quota exceeded for %v: %v\n", violation.GetSubject(), violation.GetDescription())
+			}
+		case *errdetails.RetryInfo:
+			delay, _ := ptypes.Duration(d.GetRetryDelay())
+			fmt.Fprintf(os.Stderr, "  Retry after: %v\n", delay)
+		default:
+			fmt.Fprintf(os.Stderr, "  Error detail: %v\n", detail)
+		}
+	}
+}
+
+var number = regexp.MustCompile(`^-[0-9.]`)
+
+// parseFlags parses the command flags, leaving negative numbers like "-10" as arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+		case number.MatchString(arg) || !strings.HasPrefix(arg, "-"):
+			positional = append(positional, arg)
+		default:
+			flags = append(flags, arg)
+			// the value of "-flag value"
+			name := strings.TrimLeft(arg, "-")
+			if !strings.Contains(name, "=") && i+1 < len(args) {
+				if f := fs.Lookup(name); f != nil && !isBoolFlag(f) {
+					i++
+					flags = append(flags, args[i])
+				}
+			}
+		}
+	}
+	if err := fs.Parse(flags); err != nil {
+		return nil, err
+	}
+	return positional, nil
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// readLines calls fn with every non empty line of r
+func readLines(r io.Reader, fn func(line string) error) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// readNumbers calls fn with every number of r, separated by spaces or new lines
+func readNumbers(r io.Reader, fn func(n float64) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		n, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return fmt.Errorf("cannot read number %q", scanner.Text())
+		}
+		if err := fn(n); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// splitName reads "First Last" from a line
+func splitName(line string) (string, string) {
+	fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+	if len(fields) == 1 {
+		return fields[0], ""
+	}
+	return fields[0], strings.TrimSpace(fields[1])
+}
+
+func parseFloats(args []string) ([]float64, error) {
+	numbers := make([]float64, len(args))
+	for i, arg := range args {
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read number %q", arg)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
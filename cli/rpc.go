@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoprint"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"github.com/jhump/protoreflect/grpcreflect"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// rpc commands work with any server that registers the reflection service
+var rpcCommands = map[string]*command{
+	"list": {
+		usage:       "[service]",
+		description: "lists the services of the server, or the methods of a service",
+		run:         rpcList,
+	},
+	"describe": {
+		usage:       "<symbol>",
+		description: "prints the proto definition of a service, method, message or enum",
+		run:         rpcDescribe,
+	},
+	"call": {
+		usage:       "<service>/<method> [json] [< json messages]",
+		description: "invokes a method, the request is read from the argument or stdin, one JSON message after the other for client streams",
+		run:         rpcCall,
+	},
+	"bench": {
+		usage:       "<service>/<method> [json] [--concurrency n] [--qps n] [--duration d] [--timeout d] [--out file]",
+		description: "load tests a method and reports throughput, latency percentiles and status codes, client streams send every message read",
+		run:         rpcBench,
+	},
+}
+
+func newReflectionClient(e *env) *grpcreflect.Client {
+	return grpcreflect.NewClient(e.ctx, rpb.NewServerReflectionClient(e.conn))
+}
+
+func rpcList(e *env, args []string) error {
+	fs := flag.NewFlagSet("rpc list", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	rc := newReflectionClient(e)
+	defer rc.Reset()
+
+	if len(args) == 0 {
+		services, err := rc.ListServices()
+		if err != nil {
+			return err
+		}
+		sort.Strings(services)
+		for _, service := range services {
+			fmt.Fprintln(e.out.w, service)
+		}
+		return nil
+	}
+
+	sd, err := rc.ResolveService(args[0])
+	if err != nil {
+		return err
+	}
+	for _, md := range sd.GetMethods() {
+		fmt.Fprintf(e.out.w, "%v/%v\n", sd.GetFullyQualifiedName(), md.GetName())
+	}
+	return nil
+}
+
+func rpcDescribe(e *env, args []string) error {
+	fs := flag.NewFlagSet("rpc describe", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("rpc describe needs a symbol")
+	}
+	symbol := strings.Replace(args[0], "/", ".", -1)
+
+	rc := newReflectionClient(e)
+	defer rc.Reset()
+
+	fd, err := rc.FileContainingSymbol(symbol)
+	if err != nil {
+		return err
+	}
+	d := fd.FindSymbol(symbol)
+	if d == nil {
+		return fmt.Errorf("symbol %v not found", symbol)
+	}
+	text, err := (&protoprint.Printer{}).PrintProtoToString(d)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(e.out.w, text)
+	return nil
+}
+
+// resolveMethod finds "pkg.Service/Method" (or "pkg.Service.Method") on the server
+func resolveMethod(rc *grpcreflect.Client, name string) (*desc.MethodDescriptor, error) {
+	name = strings.Replace(name, "/", ".", -1)
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return nil, fmt.Errorf("method must be <service>/<method>: %v", name)
+	}
+	sd, err := rc.ResolveService(name[:i])
+	if err != nil {
+		return nil, err
+	}
+	md := sd.FindMethodByName(name[i+1:])
+	if md == nil {
+		return nil, fmt.Errorf("service %v has no method %v", sd.GetFullyQualifiedName(), name[i+1:])
+	}
+	return md, nil
+}
+
+func rpcCall(e *env, args []string) error {
+	fs := flag.NewFlagSet("rpc call", flag.ExitOnError)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("rpc call needs a method and an optional JSON request")
+	}
+
+	rc := newReflectionClient(e)
+	defer rc.Reset()
+
+	md, err := resolveMethod(rc, args[0])
+	if err != nil {
+		return err
+	}
+
+	return invokeMethod(e.ctx, grpcdynamic.NewStub(e.conn), md, requestReader(e, md, args[1:]), e.out.print)
+}
+
+// requestReader decodes the requests of md from the optional argument, or
+// from stdin, one JSON message after the other
+func requestReader(e *env, md *desc.MethodDescriptor, args []string) func() (proto.Message, error) {
+	var input io.Reader = e.in
+	if len(args) > 0 {
+		input = strings.NewReader(args[0])
+	}
+	decoder := json.NewDecoder(input)
+	return func() (proto.Message, error) {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+		req := dynamic.NewMessage(md.GetInputType())
+		if err := req.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("invalid %v: %v", md.GetInputType().GetFullyQualifiedName(), err)
+		}
+		return req, nil
+	}
+}
+
+// invokeMethod calls md whatever its kind, next returns io.EOF when there are
+// no more requests and onResponse is called with every response.
+func invokeMethod(ctx context.Context, stub grpcdynamic.Stub, md *desc.MethodDescriptor, next func() (proto.Message, error), onResponse func(proto.Message) error) error {
+	switch {
+	case !md.IsClientStreaming() && !md.IsServerStreaming():
+		req, err := next()
+		if err != nil {
+			return err
+		}
+		res, err := stub.InvokeRpc(ctx, md, req)
+		if err != nil {
+			return err
+		}
+		return onResponse(res)
+
+	case !md.IsClientStreaming():
+		req, err := next()
+		if err != nil {
+			return err
+		}
+		stream, err := stub.InvokeRpcServerStream(ctx, md, req)
+		if err != nil {
+			return err
+		}
+		for {
+			res, err := stream.RecvMsg()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := onResponse(res); err != nil {
+				return err
+			}
+		}
+
+	case !md.IsServerStreaming():
+		stream, err := stub.InvokeRpcClientStream(ctx, md)
+		if err != nil {
+			return err
+		}
+		for {
+			req, err := next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if err := stream.SendMsg(req); err == io.EOF {
+				// the server failed the call, CloseAndReceive has the status
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+		res, err := stream.CloseAndReceive()
+		if err != nil {
+			return err
+		}
+		return onResponse(res)
+
+	default:
+		stream, err := stub.InvokeRpcBidiStream(ctx, md)
+		if err != nil {
+			return err
+		}
+		sendErr := make(chan error, 1)
+		go func() {
+			for {
+				req, err := next()
+				if err == io.EOF {
+					sendErr <- stream.CloseSend()
+					return
+				}
+				if err == nil {
+					err = stream.SendMsg(req)
+				}
+				if err != nil {
+					stream.CloseSend()
+					sendErr <- err
+					return
+				}
+			}
+		}()
+		for {
+			res, err := stream.RecvMsg()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if err := onResponse(res); err != nil {
+				return err
+			}
+		}
+		if err := <-sendErr; err != nil && err != io.EOF {
+			return err
+		}
+		return nil
+	}
+}
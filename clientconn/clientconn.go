@@ -0,0 +1,189 @@
+// Package clientconn builds the client connections shared by the commands
+// talking to the greet, calculator and blog servers, with the retry and
+// hedging policies of every method.
+//
+// The retry policies are applied by gRPC itself, grpc-go older than 1.41 only
+// does so when GRPC_GO_RETRY=on is set in the environment.
+package clientconn
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Config configures Dial, the zero value dials without TLS and with the
+// default retry policy.
+type Config struct {
+	// TLS enables TLS, trusting the certificates signed by CAFile
+	TLS        bool
+	CAFile     string
+	ServerName string
+
+	// Retry is used by the methods in RetryMethods, DefaultRetryPolicy when empty
+	Retry RetryPolicy
+
+	// Hedging sends the methods in HedgedMethods again when the first attempt
+	// is slow, instead of retrying them. Disabled when nil.
+	Hedging *HedgingPolicy
+}
+
+// RetryPolicy is the retryPolicy of a gRPC service config
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, gRPC caps it at 5
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+}
+
+// HedgingPolicy sends up to MaxAttempts copies of a call, one every Delay,
+// the first successful response wins and the other attempts are cancelled.
+type HedgingPolicy struct {
+	MaxAttempts int
+	Delay       time.Duration
+}
+
+// DefaultRetryPolicy retries 4 times, after about 0.1s, 0.2s, 0.4s and 0.8s
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       5,
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        time.Second,
+	BackoffMultiplier: 2,
+}
+
+// RetryMethods are safe to call again, they are retried when the server is UNAVAILABLE
+var RetryMethods = []string{
+	"/blog.BlogService/ReadBlog",
+	"/calculator.CalculatorService/Sum",
+}
+
+// HedgedMethods are the methods sent again when Config.Hedging is set
+var HedgedMethods = RetryMethods
+
+// IdempotentMethods are only retried when the call carries an idempotency key,
+// a retried CreateBlog would create the blog twice otherwise
+var IdempotentMethods = []string{
+	"/blog.BlogService/CreateBlog",
+}
+
+// IdempotencyKeyHeader is the metadata key of the idempotency key, which can
+// also be sent in the idempotency_key field of the request
+const IdempotencyKeyHeader = "idempotency-key"
+
+// Dial connects to address with the policies of c
+func Dial(address string, c Config) (*grpc.ClientConn, error) {
+	opts, err := dialOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Dial(address, opts...)
+}
+
+// dialOptions are the options of a connection with the policies of c
+func dialOptions(c Config) ([]grpc.DialOption, error) {
+	if c.Retry == (RetryPolicy{}) {
+		c.Retry = DefaultRetryPolicy
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(ServiceConfig(c)),
+		grpc.WithChainUnaryInterceptor(
+			idempotentRetryInterceptor(c.Retry),
+			hedgingInterceptor(c.Hedging),
+		),
+	}
+	if c.TLS {
+		creds, err := credentials.NewClientTLSFromFile(c.CAFile, c.ServerName)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	return opts, nil
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method"`
+}
+
+type retryPolicyConfig struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+type methodConfig struct {
+	Name        []methodName       `json:"name"`
+	RetryPolicy *retryPolicyConfig `json:"retryPolicy,omitempty"`
+}
+
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig"`
+}
+
+// ServiceConfig returns the JSON service config applied by Dial. A method
+// cannot have both a retry and a hedging policy, so the hedged methods are
+// left out of it when hedging is enabled.
+func ServiceConfig(c Config) string {
+	sc := serviceConfig{MethodConfig: []methodConfig{}}
+	if c.Retry.MaxAttempts < 2 {
+		// gRPC rejects a retry policy without retries
+		data, _ := json.Marshal(sc)
+		return string(data)
+	}
+
+	var names []methodName
+	for _, method := range RetryMethods {
+		if c.Hedging != nil && contains(HedgedMethods, method) {
+			continue
+		}
+		names = append(names, splitMethod(method))
+	}
+	if len(names) > 0 {
+		sc.MethodConfig = append(sc.MethodConfig, methodConfig{
+			Name: names,
+			RetryPolicy: &retryPolicyConfig{
+				MaxAttempts:          c.Retry.MaxAttempts,
+				InitialBackoff:       seconds(c.Retry.InitialBackoff),
+				MaxBackoff:           seconds(c.Retry.MaxBackoff),
+				BackoffMultiplier:    c.Retry.BackoffMultiplier,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			},
+		})
+	}
+	data, _ := json.Marshal(sc)
+	return string(data)
+}
+
+// splitMethod turns "/pkg.Service/Method" into its service config name
+func splitMethod(method string) methodName {
+	for i := len(method) - 1; i > 0; i-- {
+		if method[i] == '/' {
+			return methodName{Service: method[1:i], Method: method[i+1:]}
+		}
+	}
+	return methodName{Service: method}
+}
+
+// seconds formats d the way the service config expects durations, e.g. "0.1s"
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+func contains(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,260 @@
+package clientconn
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FernandoDevBh/grpc-go-course/blog/blogpb"
+	"github.com/FernandoDevBh/grpc-go-course/calculator/calculatorpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fast retries, the tests don't wait for the default backoff
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:       5,
+	InitialBackoff:    time.Millisecond,
+	MaxBackoff:        5 * time.Millisecond,
+	BackoffMultiplier: 2,
+}
+
+// testServer answers every method, handle decides the outcome of the nth
+// call of a method, starting at 1
+type testServer struct {
+	handle func(ctx context.Context, method string, call int) error
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+// unavailable fails the first n calls of every method with UNAVAILABLE
+func unavailable(n int) func(context.Context, string, int) error {
+	return func(ctx context.Context, method string, call int) error {
+		if call <= n {
+			return status.Error(codes.Unavailable, "try again")
+		}
+		return nil
+	}
+}
+
+// callsOf is how many times method reached the server
+func (s *testServer) callsOf(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// serve answers with an empty response, which any response message can read
+func (s *testServer) serve(srv interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	s.mu.Lock()
+	s.calls[method]++
+	call := s.calls[method]
+	s.mu.Unlock()
+
+	if err := stream.RecvMsg(&empty.Empty{}); err != nil {
+		return err
+	}
+	if err := s.handle(stream.Context(), method, call); err != nil {
+		return err
+	}
+	return stream.SendMsg(&empty.Empty{})
+}
+
+// dialTest starts a testServer and connects to it with the policies of c
+func dialTest(t *testing.T, c Config, handle func(context.Context, string, int) error) (*testServer, *grpc.ClientConn) {
+	t.Helper()
+	s := &testServer{handle: handle, calls: make(map[string]int)}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(s.serve))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	opts, err := dialOptions(c)
+	if err != nil {
+		t.Fatalf("dialOptions: %v", err)
+	}
+	opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	cc, err := grpc.Dial("bufnet", opts...)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return s, cc
+}
+
+func TestRetry(t *testing.T) {
+	keyed := metadata.AppendToOutgoingContext(context.Background(), IdempotencyKeyHeader, "key")
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    proto.Message
+		reply  proto.Message
+		// calls that reach the server, and the code of the call
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "ReadBlog",
+			method:    "/blog.BlogService/ReadBlog",
+			req:       &blogpb.ReadBlogRequest{BlogId: "id"},
+			reply:     &blogpb.ReadBlogResponse{},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "Sum",
+			method:    "/calculator.CalculatorService/Sum",
+			req:       &calculatorpb.SumRequest{},
+			reply:     &calculatorpb.SumResponse{},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "CreateBlog with an idempotency key header",
+			ctx:       keyed,
+			method:    "/blog.BlogService/CreateBlog",
+			req:       &blogpb.CreateBlogRequest{},
+			reply:     &blogpb.CreateBlogResponse{},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "CreateBlog with an idempotency key field",
+			method:    "/blog.BlogService/CreateBlog",
+			req:       &blogpb.CreateBlogRequest{IdempotencyKey: "key"},
+			reply:     &blogpb.CreateBlogResponse{},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "CreateBlog without an idempotency key",
+			method:    "/blog.BlogService/CreateBlog",
+			req:       &blogpb.CreateBlogRequest{},
+			reply:     &blogpb.CreateBlogResponse{},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			name:      "UpdateBlog",
+			ctx:       keyed,
+			method:    "/blog.BlogService/UpdateBlog",
+			req:       &blogpb.UpdateBlogRequest{},
+			reply:     &blogpb.UpdateBlogResponse{},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			name:      "SquareRoot",
+			method:    "/calculator.CalculatorService/SquareRoot",
+			req:       &calculatorpb.SquareRootRequest{},
+			reply:     &calculatorpb.SquareRootResponse{},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, cc := dialTest(t, Config{Retry: testRetryPolicy}, unavailable(2))
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			err := cc.Invoke(ctx, tt.method, tt.req, tt.reply)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %v, want %v: %v", code, tt.wantCode, err)
+			}
+			if calls := s.callsOf(tt.method); calls != tt.wantCalls {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	s, cc := dialTest(t, Config{Retry: testRetryPolicy}, unavailable(10))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, IdempotencyKeyHeader, "key")
+	for _, method := range []string{"/calculator.CalculatorService/Sum", "/blog.BlogService/CreateBlog"} {
+		err := cc.Invoke(ctx, method, &empty.Empty{}, &empty.Empty{})
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("%v: code = %v, want %v", method, status.Code(err), codes.Unavailable)
+		}
+		if calls := s.callsOf(method); calls != testRetryPolicy.MaxAttempts {
+			t.Errorf("%v: calls = %v, want %v", method, calls, testRetryPolicy.MaxAttempts)
+		}
+	}
+}
+
+func TestHedgingCancelsLosers(t *testing.T) {
+	const method = "/blog.BlogService/ReadBlog"
+	// the first attempt hangs until it is cancelled, the second one wins
+	cancelled := make(chan struct{})
+	handle := func(ctx context.Context, _ string, call int) error {
+		if call > 1 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return status.Error(codes.DeadlineExceeded, "the losing attempt was not cancelled")
+		}
+	}
+	c := Config{
+		Retry:   testRetryPolicy,
+		Hedging: &HedgingPolicy{MaxAttempts: 3, Delay: 100 * time.Millisecond},
+	}
+	s, cc := dialTest(t, c, handle)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := cc.Invoke(ctx, method, &blogpb.ReadBlogRequest{}, &blogpb.ReadBlogResponse{}); err != nil {
+		t.Fatalf("ReadBlog: %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the first attempt was not cancelled once the second one succeeded")
+	}
+	if calls := s.callsOf(method); calls != 2 {
+		t.Errorf("calls = %v, want 2", calls)
+	}
+}
+
+func TestHedgingUnavailable(t *testing.T) {
+	const method = "/calculator.CalculatorService/Sum"
+	c := Config{
+		Retry:   testRetryPolicy,
+		Hedging: &HedgingPolicy{MaxAttempts: 3, Delay: time.Second},
+	}
+	// an UNAVAILABLE attempt is sent again without waiting for the delay
+	s, cc := dialTest(t, c, unavailable(2))
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if err := cc.Invoke(ctx, method, &calculatorpb.SumRequest{}, &calculatorpb.SumResponse{}); err != nil {
+		t.Fatalf("Sum: %v", err)
+	}
+	if calls := s.callsOf(method); calls != 3 {
+		t.Errorf("calls = %v, want 3", calls)
+	}
+}
@@ -0,0 +1,123 @@
+package clientconn
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// idempotentRetryInterceptor retries the IdempotentMethods on UNAVAILABLE,
+// with the backoff of p, when the call carries an idempotency key. The
+// service config cannot look at the metadata, so this is done here.
+func idempotentRetryInterceptor(p RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !contains(IdempotentMethods, method) || !hasIdempotencyKey(ctx, req) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= p.MaxAttempts {
+				return err
+			}
+			select {
+			case <-time.After(backoff(p, attempt)):
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			}
+		}
+	}
+}
+
+// hasIdempotencyKey looks for the key in the metadata and in the request
+func hasIdempotencyKey(ctx context.Context, req interface{}) bool {
+	if r, ok := req.(interface{ GetIdempotencyKey() string }); ok && r.GetIdempotencyKey() != "" {
+		return true
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return len(md.Get(IdempotencyKeyHeader)) > 0
+}
+
+// backoff returns the random pause before the next attempt, like gRPC does:
+// up to InitialBackoff*BackoffMultiplier^(attempt-1), capped at MaxBackoff
+func backoff(p RetryPolicy, attempt int) time.Duration {
+	max := float64(p.InitialBackoff) * math.Pow(p.BackoffMultiplier, float64(attempt-1))
+	if max > float64(p.MaxBackoff) {
+		max = float64(p.MaxBackoff)
+	}
+	return time.Duration(rand.Float64() * max)
+}
+
+// hedgingInterceptor sends the HedgedMethods again every p.Delay until one
+// attempt succeeds or fails with something else than UNAVAILABLE, which is
+// then the result of the call. Hedging is disabled when p is nil.
+func hedgingInterceptor(p *HedgingPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if p == nil || p.MaxAttempts < 2 || !contains(HedgedMethods, method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		// the attempts still running are cancelled once the call is decided
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			reply interface{}
+			err   error
+		}
+		results := make(chan result, p.MaxAttempts)
+		attempt := func() {
+			// every attempt needs its own response
+			r := reflect.New(reflect.TypeOf(reply).Elem()).Interface()
+			results <- result{r, invoker(ctx, method, req, r, cc, opts...)}
+		}
+
+		go attempt()
+		started, running := 1, 1
+		timer := time.NewTimer(p.Delay)
+		defer timer.Stop()
+		var lastErr error
+		for {
+			select {
+			case res := <-results:
+				running--
+				if res.err == nil {
+					proto.Merge(reply.(proto.Message), res.reply.(proto.Message))
+					return nil
+				}
+				if status.Code(res.err) != codes.Unavailable {
+					return res.err
+				}
+				lastErr = res.err
+				if started < p.MaxAttempts {
+					// no point in waiting for the delay, the server is unavailable
+					if !timer.Stop() {
+						<-timer.C
+					}
+					go attempt()
+					started++
+					running++
+					timer.Reset(p.Delay)
+				} else if running == 0 {
+					return lastErr
+				}
+			case <-timer.C:
+				if started < p.MaxAttempts {
+					go attempt()
+					started++
+					running++
+					timer.Reset(p.Delay)
+				}
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			}
+		}
+	}
+}
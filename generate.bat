@@ -1,3 +1,4 @@
 protoc .\greet\greetpb\greet.proto --go_out=plugins=grpc:.
 protoc .\calculator\calculatorpb\calculator.proto --go_out=plugins=grpc:.
-protoc .\blog\blogpb\blog.proto --go_out=plugins=grpc:.
\ No newline at end of file
+protoc .\blog\blogpb\blog.proto --go_out=plugins=grpc:.
+protoc .\validation\validationpb\validation.proto --go_out=paths=source_relative:.
\ No newline at end of file
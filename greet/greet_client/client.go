@@ -1,261 +0,0 @@
-package main
-
-import (
-	"context"
-	"fmt"
-	"io"
-	"log"
-	"time"
-
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials"
-
-	"github.com/FernandoDevBh/grpc-go-course/greet/greetpb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
-)
-
-func main() {
-	fmt.Println("Hello I'm a client")
-	tls := true
-	opts := grpc.WithInsecure()
-	if tls {
-		certFile := "ssl/ca.crt" // Certificate Authority Trust certificate
-
-		creds, sslErr := credentials.NewClientTLSFromFile(certFile, "")
-
-		opts = grpc.WithTransportCredentials(creds)
-
-		if sslErr != nil {
-			log.Fatalf("Error while loading CA trust certificate: %v", sslErr)
-			return
-		}
-	}
-
-	cc, err := grpc.Dial("localhost:50051", opts)
-
-	if err != nil {
-		log.Fatalf("Could not connect: %v", err)
-	}
-	defer cc.Close()
-
-	c := greetpb.NewGreetServiceClient(cc)
-
-	doUnary(c)
-
-	//doServerStreaming(c)
-
-	//doClientStreaming(c)
-
-	//doBiDiStreaming(c)
-
-	// doUnaryCallWithDeadLine(c, 1*time.Second)
-	// doUnaryCallWithDeadLine(c, 5*time.Second)
-}
-
-func doUnary(c greetpb.GreetServiceClient) {
-	fmt.Println("Starting to do a Unary RPC...")
-	req := &greetpb.GreetRequest{
-		Greeting: &greetpb.Greeting{
-			FirstName: "Fernando",
-			LastName:  "Ferreira",
-		},
-	}
-
-	res, err := c.Greet(context.Background(), req)
-	if err != nil {
-		log.Fatalf("error while calling Greet RPC: %v", err)
-	}
-
-	log.Printf("Response from Greet: %v", res.Result)
-}
-
-func doServerStreaming(c greetpb.GreetServiceClient) {
-	fmt.Println("Starting to do a Streaming RPC...")
-	req := &greetpb.GreetManyTimesRequest{
-		Greeting: &greetpb.Greeting{
-			FirstName: "Fernando",
-			LastName:  "Ferreira",
-		},
-	}
-	resStream, err := c.GreetManyTimes(context.Background(), req)
-
-	if err != nil {
-		log.Fatalf("error while calling GreetManyTimes RPC: %v", err)
-	}
-	for {
-		msg, err := resStream.Recv()
-		if err == io.EOF {
-			// we've reached the end of the stream
-			break
-		}
-		if err != nil {
-			log.Fatalf("error while reading stream: %v", err)
-		}
-		log.Printf("Response from GreetManyTimes: %v\n", msg.GetResult())
-	}
-}
-
-func doClientStreaming(c greetpb.GreetServiceClient) {
-	fmt.Println("Starting to do a Client Streaming RPC...")
-	rqs := []*greetpb.LongGreetRequest{
-		&greetpb.LongGreetRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Fernando",
-				LastName:  "Ferreira",
-			},
-		},
-		&greetpb.LongGreetRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Jean",
-				LastName:  "Ferreira",
-			},
-		},
-		&greetpb.LongGreetRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Adriano",
-				LastName:  "Ferreira",
-			},
-		},
-		&greetpb.LongGreetRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Carlos",
-				LastName:  "Fernandes",
-			},
-		},
-		&greetpb.LongGreetRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Ana",
-				LastName:  "dos Santos",
-			},
-		},
-	}
-
-	stream, err := c.LongGreet(context.Background())
-
-	if err != nil {
-		log.Fatalf("error while calling LongGreet: %v", err)
-	}
-
-	// we iterate over our slice and send each message individually
-	for _, req := range rqs {
-		fmt.Printf("Sending req: %v\n", req)
-		stream.Send(req)
-		time.Sleep(1000 * time.Millisecond)
-	}
-
-	res, err := stream.CloseAndRecv()
-
-	if err != nil {
-		log.Fatalf("error while receiving response from LongGreet: %v", err)
-	}
-
-	fmt.Printf("LongGreet Response: %v\n", res)
-}
-
-func doBiDiStreaming(c greetpb.GreetServiceClient) {
-	fmt.Println("Starting to do a BiDi Streaming RPC...")
-
-	rqs := []*greetpb.GreetEveryoneRequest{
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Fernando",
-				LastName:  "Ferreira",
-			},
-		},
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Jean",
-				LastName:  "Ferreira",
-			},
-		},
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Adriano",
-				LastName:  "Ferreira",
-			},
-		},
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Carlos",
-				LastName:  "Fernandes",
-			},
-		},
-		&greetpb.GreetEveryoneRequest{
-			Greeting: &greetpb.Greeting{
-				FirstName: "Ana",
-				LastName:  "dos Santos",
-			},
-		},
-	}
-
-	// we create a streaming by invoking the client
-	stream, err := c.GreetEveryone(context.Background())
-	if err != nil {
-		log.Fatalf("error while creating stream LongGreet: %v", err)
-		return
-	}
-
-	waitc := make(chan struct{})
-
-	// we send a bunch of messages to the client (go routines)
-	go func() {
-		// function to send a bunch of messages
-		for _, rq := range rqs {
-			fmt.Printf("Sending message: %v\n", rq)
-			stream.Send(rq)
-			time.Sleep(1000 * time.Millisecond)
-		}
-		stream.CloseSend()
-	}()
-
-	// we receive a bunch of messages from the client (go routines)
-	go func() {
-		// function to send a bunch of messages
-		for {
-			res, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				log.Fatalf("error while receiving: %v", err)
-				break
-			}
-			fmt.Printf("Received: %v\n", res.GetResult())
-		}
-		close(waitc)
-	}()
-
-	// block until everything is done
-	<-waitc
-}
-
-func doUnaryCallWithDeadLine(c greetpb.GreetServiceClient, timeout time.Duration) {
-	fmt.Println("Starting to do a UnaryWithDeadLine RPC...")
-	req := &greetpb.GreetWithDeadLineRequest{
-		Greeting: &greetpb.Greeting{
-			FirstName: "Fernando",
-			LastName:  "Ferreira",
-		},
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	res, err := c.GreetWithDeadLine(ctx, req)
-	if err != nil {
-		statusErr, ok := status.FromError(err)
-		if ok {
-			if statusErr.Code() == codes.DeadlineExceeded {
-				fmt.Println("Timeout was hit! Deadline was exceeded")
-			} else {
-				fmt.Printf("unexpected error: %v\n", statusErr)
-			}
-		} else {
-			log.Fatalf("error while calling GreetWithDeadLine RPC %v", err)
-		}
-		return
-	}
-
-	log.Printf("Response from GreetWithDeadLine: %v", res.Result)
-}
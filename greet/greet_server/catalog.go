@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/FernandoDevBh/grpc-go-course/greet/greetpb"
+	"google.golang.org/grpc/metadata"
+)
+
+// locale used when none of the requested ones is in the catalog
+const defaultLocale = "en"
+
+// metadata keys a client can use to ask for a language
+var acceptLanguageKeys = []string{"grpc-accept-language", "accept-language"}
+
+// greetingMessages is the content of a locales/<tag>.json file
+type greetingMessages struct {
+	Informal string `json:"informal"`
+	Formal   string `json:"formal"`
+	FullName string `json:"full_name"`
+}
+
+type catalog struct {
+	messages map[string]*greetingMessages
+}
+
+// loadCatalog reads every <tag>.json file of dir
+func loadCatalog(dir string) (*catalog, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &catalog{messages: map[string]*greetingMessages{}}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		m := &greetingMessages{}
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("cannot parse %v: %v", file, err)
+		}
+		if m.Informal == "" || m.Formal == "" {
+			return nil, fmt.Errorf("%v must define the informal and formal greetings", file)
+		}
+		if m.FullName == "" {
+			m.FullName = "{first_name} {last_name}"
+		}
+		tag := normalizeLocale(strings.TrimSuffix(filepath.Base(file), ".json"))
+		c.messages[tag] = m
+	}
+
+	if _, ok := c.messages[defaultLocale]; !ok {
+		return nil, fmt.Errorf("the catalog at %v has no %v.json", dir, defaultLocale)
+	}
+	return c, nil
+}
+
+// greet returns the greeting for g and the locale it was written in
+func (c *catalog) greet(ctx context.Context, g *greetpb.Greeting) (string, string) {
+	candidates := acceptedLanguages(ctx)
+	if g.GetLocale() != "" {
+		candidates = append([]string{g.GetLocale()}, candidates...)
+	}
+	locale, m := c.resolve(candidates)
+
+	r := strings.NewReplacer(
+		"{first_name}", g.GetFirstName(),
+		"{last_name}", g.GetLastName(),
+	)
+	// collapse the spaces left by a missing name
+	fullName := strings.Join(strings.Fields(r.Replace(m.FullName)), " ")
+
+	template := m.Informal
+	if g.GetFormal() {
+		template = m.Formal
+	}
+	greeting := strings.NewReplacer(
+		"{first_name}", g.GetFirstName(),
+		"{last_name}", g.GetLastName(),
+		"{full_name}", fullName,
+	).Replace(template)
+	return greeting, locale
+}
+
+// resolve picks the first candidate in the catalog, trying each one from the
+// most specific tag to its base language (zh-Hant-TW, zh-Hant, zh) before the
+// next candidate, and falls back to the default locale.
+func (c *catalog) resolve(candidates []string) (string, *greetingMessages) {
+	for _, candidate := range candidates {
+		tag := normalizeLocale(candidate)
+		for tag != "" {
+			if m, ok := c.messages[tag]; ok {
+				return tag, m
+			}
+			i := strings.LastIndex(tag, "-")
+			if i < 0 {
+				break
+			}
+			tag = tag[:i]
+		}
+	}
+	return defaultLocale, c.messages[defaultLocale]
+}
+
+func normalizeLocale(tag string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(tag), "_", "-", -1))
+}
+
+// acceptedLanguages parses the accept-language metadata ("pt-BR,pt;q=0.9,en;q=0.5")
+// ordered by preference
+func acceptedLanguages(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	type language struct {
+		tag     string
+		quality float64
+	}
+	var languages []language
+	for _, key := range acceptLanguageKeys {
+		for _, value := range md.Get(key) {
+			for _, part := range strings.Split(value, ",") {
+				fields := strings.Split(part, ";")
+				tag := strings.TrimSpace(fields[0])
+				if tag == "" || tag == "*" {
+					continue
+				}
+				quality := 1.0
+				for _, param := range fields[1:] {
+					param = strings.TrimSpace(param)
+					if strings.HasPrefix(param, "q=") {
+						if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+							quality = q
+						}
+					}
+				}
+				if quality > 0 {
+					languages = append(languages, language{tag: tag, quality: quality})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+	tags := make([]string, len(languages))
+	for i, l := range languages {
+		tags[i] = l.tag
+	}
+	return tags
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/FernandoDevBh/grpc-go-course/greet/greetpb"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// historyStore keeps every greeting handled by the server
+type historyStore interface {
+	Add(rec *greetpb.GreetingRecord) error
+	// List calls fn with the matching records, oldest first, until fn fails
+	List(filter *greetpb.GetGreetingHistoryRequest, fn func(*greetpb.GreetingRecord) error) error
+}
+
+// newGreetingRecord stamps a greeting handled by rpc with the current time
+func newGreetingRecord(rpc string, g *greetpb.Greeting, result string) *greetpb.GreetingRecord {
+	return &greetpb.GreetingRecord{
+		Greeting:  g,
+		Rpc:       rpc,
+		Result:    result,
+		GreetedAt: ptypes.TimestampNow(),
+	}
+}
+
+// matches tells if rec passes the time range and name filters
+func matches(filter *greetpb.GetGreetingHistoryRequest, rec *greetpb.GreetingRecord) bool {
+	at, err := ptypes.Timestamp(rec.GetGreetedAt())
+	if err != nil {
+		return false
+	}
+	if filter.GetSince() != nil {
+		since, err := ptypes.Timestamp(filter.GetSince())
+		if err == nil && at.Before(since) {
+			return false
+		}
+	}
+	if filter.GetUntil() != nil {
+		until, err := ptypes.Timestamp(filter.GetUntil())
+		if err == nil && !at.Before(until) {
+			return false
+		}
+	}
+
+	name := strings.TrimSpace(filter.GetName())
+	if name == "" {
+		return true
+	}
+	first := rec.GetGreeting().GetFirstName()
+	last := rec.GetGreeting().GetLastName()
+	for _, candidate := range []string{first, last, strings.TrimSpace(first + " " + last)} {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryHistory loses the history when the server stops, it keeps the
+// latest records in a ring
+type memoryHistory struct {
+	mu      sync.RWMutex
+	records []*greetpb.GreetingRecord
+	// where the next record goes once the ring is full, it is the oldest
+	next int
+	max  int
+}
+
+func newMemoryHistory(max int) *memoryHistory {
+	return &memoryHistory{max: max}
+}
+
+func (h *memoryHistory) Add(rec *greetpb.GreetingRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.records) < h.max {
+		h.records = append(h.records, rec)
+		return nil
+	}
+	h.records[h.next] = rec
+	h.next = (h.next + 1) % h.max
+	return nil
+}
+
+func (h *memoryHistory) List(filter *greetpb.GetGreetingHistoryRequest, fn func(*greetpb.GreetingRecord) error) error {
+	// don't hold the lock while the client reads
+	h.mu.RLock()
+	records := make([]*greetpb.GreetingRecord, 0, len(h.records))
+	records = append(records, h.records[h.next:]...)
+	records = append(records, h.records[:h.next]...)
+	h.mu.RUnlock()
+
+	for _, rec := range records {
+		if !matches(filter, rec) {
+			continue
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileHistory appends the records to a JSON Lines file
+type fileHistory struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+func newFileHistory(path string) (*fileHistory, error) {
+	if err := dropTornLine(path); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileHistory{path: path, file: file}, nil
+}
+
+// dropTornLine truncates a last line left without its newline by a crash,
+// the next record would be appended to it otherwise
+func dropTornLine(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+	end := bytes.LastIndexByte(data, '\n') + 1
+	log.Printf("Dropping the torn last line of the greeting history: %q", data[end:])
+	return os.Truncate(path, int64(end))
+}
+
+func (h *fileHistory) Add(rec *greetpb.GreetingRecord) error {
+	line, err := (&jsonpb.Marshaler{}).MarshalToString(rec)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.file.WriteString(line + "\n")
+	return err
+}
+
+func (h *fileHistory) List(filter *greetpb.GetGreetingHistoryRequest, fn func(*greetpb.GreetingRecord) error) error {
+	file, err := os.Open(h.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	// an invalid line fails the listing unless it is the last one, an Add
+	// may still be writing it
+	var invalid error
+	for number := 1; scanner.Scan(); number++ {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if invalid != nil {
+			return invalid
+		}
+		rec := &greetpb.GreetingRecord{}
+		if err := jsonpb.Unmarshal(bytes.NewReader(line), rec); err != nil {
+			invalid = fmt.Errorf("line %v of %v is corrupt: %v", number, h.path, err)
+			continue
+		}
+		if !matches(filter, rec) {
+			continue
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/FernandoDevBh/grpc-go-course/greet/greetpb"
+)
+
+const defaultRoom = "lobby"
+
+// how many events can wait for a member before new ones are dropped
+const memberBufferSize = 32
+
+// member is a GreetEveryone stream that joined a room
+type member struct {
+	name    string
+	room    string
+	out     chan *greetpb.GreetEveryoneResponse
+	dropped int
+}
+
+// hub fans the greetings of a room out to all of its members
+type hub struct {
+	mu    sync.Mutex
+	rooms map[string]map[*member]struct{}
+}
+
+func newHub() *hub {
+	return &hub{rooms: map[string]map[*member]struct{}{}}
+}
+
+func (h *hub) join(room string, name string) *member {
+	if room == "" {
+		room = defaultRoom
+	}
+	m := &member{
+		name: name,
+		room: room,
+		out:  make(chan *greetpb.GreetEveryoneResponse, memberBufferSize),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.rooms[room] == nil {
+		h.rooms[room] = map[*member]struct{}{}
+	}
+	h.rooms[room][m] = struct{}{}
+	h.broadcastLocked(m, &greetpb.GreetEveryoneResponse{
+		Result: fmt.Sprintf("%v joined %v", name, room),
+		Event:  greetpb.GreetEveryoneResponse_JOINED,
+		Room:   room,
+		From:   name,
+	})
+	return m
+}
+
+// leave removes the member and closes its channel
+func (h *hub) leave(m *member) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.rooms[m.room], m)
+	if len(h.rooms[m.room]) == 0 {
+		delete(h.rooms, m.room)
+	}
+	close(m.out)
+	if m.dropped > 0 {
+		log.Printf("%v missed %v events in %v", m.name, m.dropped, m.room)
+	}
+	h.broadcastLocked(m, &greetpb.GreetEveryoneResponse{
+		Result: fmt.Sprintf("%v left %v", m.name, m.room),
+		Event:  greetpb.GreetEveryoneResponse_LEFT,
+		Room:   m.room,
+		From:   m.name,
+	})
+}
+
+// greet sends a greeting from m to the other members of its room
+func (h *hub) greet(m *member, result string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.broadcastLocked(m, &greetpb.GreetEveryoneResponse{
+		Result: result,
+		Event:  greetpb.GreetEveryoneResponse_GREETING,
+		Room:   m.room,
+		From:   m.name,
+	})
+}
+
+// broadcastLocked never blocks: a member whose buffer is full misses the event
+func (h *hub) broadcastLocked(from *member, res *greetpb.GreetEveryoneResponse) {
+	for m := range h.rooms[from.room] {
+		if m == from {
+			continue
+		}
+		select {
+		case m.out <- res:
+		default:
+			m.dropped++
+		}
+	}
+}
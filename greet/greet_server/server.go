@@ -2,99 +2,235 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
 	"github.com/FernandoDevBh/grpc-go-course/greet/greetpb"
+	"github.com/FernandoDevBh/grpc-go-course/validation"
 
 	"google.golang.org/grpc"
 )
 
-type server struct{}
+// GreetManyTimes limits
+const defaultGreetCount = 10
+const maxGreetCount = 1000
+const defaultGreetInterval = 1000 * time.Millisecond
+const minGreetInterval = 10 * time.Millisecond
+const maxGreetInterval = time.Minute
+const defaultGreetTemplate = "{greeting} number {number}"
 
-func (*server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
+// directory with the greetings of every language
+const localesDir = "greet/greet_server/locales"
+
+var historyFile = flag.String("history", "", "JSON Lines file to keep the greeting history in, kept in memory when empty")
+var historyMax = flag.Int("history-max", 10000, "greetings kept by the in-memory history, the oldest are dropped first")
+var longGreetMaxMessages = flag.Int("long-greet-max-messages", 1000, "greetings a LongGreet stream can send")
+var longGreetMaxBytes = flag.Int("long-greet-max-bytes", 1<<20, "size of the combined LongGreet result, in bytes")
+
+type server struct {
+	catalog              *catalog
+	hub                  *hub
+	history              historyStore
+	longGreetMaxMessages int
+	longGreetMaxBytes    int
+}
+
+// record adds a greeting to the history, a failure doesn't fail the RPC
+func (s *server) record(rpc string, g *greetpb.Greeting, result string) {
+	if err := s.history.Add(newGreetingRecord(rpc, g, result)); err != nil {
+		log.Printf("Cannot record %v greeting: %v", rpc, err)
+	}
+}
+
+// setContentLanguage tells the client which language the greeting was written in
+func setContentLanguage(ctx context.Context, locale string) {
+	if err := grpc.SetHeader(ctx, metadata.Pairs("content-language", locale)); err != nil {
+		log.Printf("Cannot set content-language header: %v", err)
+	}
+}
+
+func (s *server) Greet(ctx context.Context, req *greetpb.GreetRequest) (*greetpb.GreetResponse, error) {
 	fmt.Printf("Greet function was invoked with %v\n", req)
-	firstName := req.GetGreeting().GetFirstName()
-	result := "Hello " + firstName
+	result, locale := s.catalog.greet(ctx, req.GetGreeting())
+	setContentLanguage(ctx, locale)
+	s.record("Greet", req.GetGreeting(), result)
 	res := &greetpb.GreetResponse{
 		Result: result,
 	}
 	return res, nil
 }
 
-func (*server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
+func (s *server) GreetManyTimes(req *greetpb.GreetManyTimesRequest, stream greetpb.GreetService_GreetManyTimesServer) error {
 	fmt.Printf("GreetManyTimes function was invoked with %v\n", req)
-	firstName := req.GetGreeting().GetFirstName()
-	for i := 0; i < 10; i++ {
-		result := "Hello " + firstName + " number " + strconv.Itoa(i)
+
+	count := int(req.GetCount())
+	if count == 0 {
+		count = defaultGreetCount
+	}
+	if !req.GetUntilCancelled() && (count < 0 || count > maxGreetCount) {
+		return status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Count must be between 1 and %v: %v", maxGreetCount, count),
+		)
+	}
+
+	interval := time.Duration(req.GetIntervalMs()) * time.Millisecond
+	if interval == 0 {
+		interval = defaultGreetInterval
+	}
+	if interval < minGreetInterval || interval > maxGreetInterval {
+		return status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("Interval must be between %v and %v: %v", minGreetInterval, maxGreetInterval, interval),
+		)
+	}
+
+	template := req.GetTemplate()
+	if template == "" {
+		template = defaultGreetTemplate
+	}
+
+	ctx := stream.Context()
+	greeting, locale := s.catalog.greet(ctx, req.GetGreeting())
+	setContentLanguage(ctx, locale)
+	s.record("GreetManyTimes", req.GetGreeting(), greeting)
+	for i := 0; req.GetUntilCancelled() || i < count; i++ {
+		result := strings.NewReplacer(
+			"{greeting}", greeting,
+			"{first_name}", req.GetGreeting().GetFirstName(),
+			"{last_name}", req.GetGreeting().GetLastName(),
+			"{number}", strconv.Itoa(i),
+		).Replace(template)
 		res := &greetpb.GreetManyTimesResponse{
 			Result: result,
 		}
-		stream.Send(res)
-		time.Sleep(1000 * time.Millisecond)
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			// the client canceled the request
+			fmt.Println("The client canceled the request!")
+			return status.Error(codes.Canceled, "The client canceled the request")
+		case <-time.After(interval):
+		}
 	}
 	return nil
 }
 
-func (*server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
+func (s *server) LongGreet(stream greetpb.GreetService_LongGreetServer) error {
 	fmt.Printf("LongGreet function was invoked with streaming request: %v\n", stream)
-	result := ""
+	var result strings.Builder
+	res := &greetpb.LongGreetResponse{}
+	seen := map[string]bool{}
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			// We have finished reading the client stream
-			return stream.SendAndClose(&greetpb.LongGreetResponse{
-				Result: result,
-			})
+			res.Result = result.String()
+			return stream.SendAndClose(res)
 		}
 
 		if err != nil {
-			log.Fatalf("Error while reading client stream: %v", err)
+			log.Printf("Error while reading client stream: %v", err)
+			return err
 		}
 
-		firstName := req.GetGreeting().GetFirstName()
+		res.Count++
+		if int(res.Count) > s.longGreetMaxMessages {
+			return status.Error(
+				codes.ResourceExhausted,
+				fmt.Sprintf("LongGreet accepts at most %v greetings", s.longGreetMaxMessages),
+			)
+		}
 
-		result += "Hello " + firstName + "! "
+		name := strings.TrimSpace(req.GetGreeting().GetFirstName() + " " + req.GetGreeting().GetLastName())
+		if seen[strings.ToLower(name)] {
+			res.DuplicatesRemoved++
+			continue
+		}
+
+		greeting, _ := s.catalog.greet(stream.Context(), req.GetGreeting())
+		if result.Len()+len(greeting)+len("! ") > s.longGreetMaxBytes {
+			return status.Error(
+				codes.ResourceExhausted,
+				fmt.Sprintf("LongGreet result cannot be larger than %v bytes", s.longGreetMaxBytes),
+			)
+		}
+		s.record("LongGreet", req.GetGreeting(), greeting)
+
+		seen[strings.ToLower(name)] = true
+		res.Names = append(res.Names, name)
+		result.WriteString(greeting + "! ")
 	}
 }
 
-func (*server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
+func (s *server) GreetEveryone(stream greetpb.GreetService_GreetEveryoneServer) error {
 	fmt.Printf("GreetEveryone function was invoked with streaming request: %v\n", stream)
 
-	for {
-		req, err := stream.Recv()
-		if err == io.EOF {
-			return nil
-		}
+	// the first message joins the room
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error while reading client stream: %v", err)
+		return err
+	}
 
-		if err != nil {
-			log.Fatalf("Error while reading client stream: %v", err)
-			return err
+	name := strings.TrimSpace(req.GetGreeting().GetFirstName() + " " + req.GetGreeting().GetLastName())
+	m := s.hub.join(req.GetRoom(), name)
+
+	// the events of the room are sent from a single go routine
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for res := range m.out {
+			if err := stream.Send(res); err != nil {
+				log.Printf("Error while sending data to client: %v", err)
+				// keep draining so the member can leave
+				for range m.out {
+				}
+				return
+			}
 		}
+	}()
 
-		firstName := req.GetGreeting().GetFirstName()
-		result := "Hello " + firstName + "! "
-		err = stream.Send(&greetpb.GreetEveryoneResponse{
-			Result: result,
-		})
+	defer func() {
+		s.hub.leave(m)
+		<-sent
+	}()
 
+	for {
+		greeting, _ := s.catalog.greet(stream.Context(), req.GetGreeting())
+		s.record("GreetEveryone", req.GetGreeting(), greeting)
+		s.hub.greet(m, greeting+"! ")
+
+		req, err = stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
-			log.Fatalf("Error while sending data to client: %v", err)
+			log.Printf("Error while reading client stream: %v", err)
 			return err
-
 		}
 	}
 }
 
-func (*server) GreetWithDeadLine(c context.Context, req *greetpb.GreetWithDeadLineRequest) (*greetpb.GreetWithDeadLineResponse, error) {
+func (s *server) GreetWithDeadLine(c context.Context, req *greetpb.GreetWithDeadLineRequest) (*greetpb.GreetWithDeadLineResponse, error) {
 	fmt.Printf("GreetWithDeadLine function was invoked with %v\n", req)
 	for i := 0; i < 3; i++ {
 		if c.Err() == context.Canceled {
@@ -104,23 +240,65 @@ func (*server) GreetWithDeadLine(c context.Context, req *greetpb.GreetWithDeadLi
 		}
 		time.Sleep(1 * time.Second)
 	}
-	firstName := req.GetGreeting().GetFirstName()
-	result := "Hello " + firstName
+	result, locale := s.catalog.greet(c, req.GetGreeting())
+	setContentLanguage(c, locale)
 	res := &greetpb.GreetWithDeadLineResponse{
 		Result: result,
 	}
 	return res, nil
 }
 
+func (s *server) GetGreetingHistory(req *greetpb.GetGreetingHistoryRequest, stream greetpb.GreetService_GetGreetingHistoryServer) error {
+	fmt.Printf("GetGreetingHistory function was invoked with %v\n", req)
+	err := s.history.List(req, func(rec *greetpb.GreetingRecord) error {
+		return stream.Send(&greetpb.GetGreetingHistoryResponse{
+			Record: rec,
+		})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, fmt.Sprintf("Cannot read the greeting history: %v", err))
+	}
+	return nil
+}
+
 func main() {
+	flag.Parse()
 	fmt.Println("Hello World")
 
+	catalog, err := loadCatalog(localesDir)
+	if err != nil {
+		log.Fatalf("Failed loading greetings catalog: %v", err)
+	}
+
+	if *historyMax <= 0 {
+		log.Fatalf("-history-max must be positive: %v", *historyMax)
+	}
+	if *longGreetMaxMessages <= 0 {
+		log.Fatalf("-long-greet-max-messages must be positive: %v", *longGreetMaxMessages)
+	}
+	if *longGreetMaxBytes <= 0 {
+		log.Fatalf("-long-greet-max-bytes must be positive: %v", *longGreetMaxBytes)
+	}
+	var history historyStore = newMemoryHistory(*historyMax)
+	if *historyFile != "" {
+		history, err = newFileHistory(*historyFile)
+		if err != nil {
+			log.Fatalf("Failed opening greeting history: %v", err)
+		}
+	}
+
 	lis, err := net.Listen("tcp", "0.0.0.0:50051")
 
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	opts := []grpc.ServerOption{}
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(validation.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(validation.StreamServerInterceptor()),
+	}
 
 	tls := true
 	if tls {
@@ -139,7 +317,16 @@ func main() {
 
 	s := grpc.NewServer(opts...)
 
-	greetpb.RegisterGreetServiceServer(s, &server{})
+	greetpb.RegisterGreetServiceServer(s, &server{
+		catalog:              catalog,
+		hub:                  newHub(),
+		history:              history,
+		longGreetMaxMessages: *longGreetMaxMessages,
+		longGreetMaxBytes:    *longGreetMaxBytes,
+	})
+
+	// Register reflection service on gRPC server.
+	reflection.Register(s)
 
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to Server: %v", err)
@@ -6,7 +6,9 @@ package greetpb
 import (
 	context "context"
 	fmt "fmt"
+	_ "github.com/FernandoDevBh/grpc-go-course/validation/validationpb"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -22,9 +24,40 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type GreetEveryoneResponse_Event int32
+
+const (
+	GreetEveryoneResponse_GREETING GreetEveryoneResponse_Event = 0
+	GreetEveryoneResponse_JOINED   GreetEveryoneResponse_Event = 1
+	GreetEveryoneResponse_LEFT     GreetEveryoneResponse_Event = 2
+)
+
+var GreetEveryoneResponse_Event_name = map[int32]string{
+	0: "GREETING",
+	1: "JOINED",
+	2: "LEFT",
+}
+
+var GreetEveryoneResponse_Event_value = map[string]int32{
+	"GREETING": 0,
+	"JOINED":   1,
+	"LEFT":     2,
+}
+
+func (x GreetEveryoneResponse_Event) String() string {
+	return proto.EnumName(GreetEveryoneResponse_Event_name, int32(x))
+}
+
+func (GreetEveryoneResponse_Event) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fe6f881da19a2871, []int{8, 0}
+}
+
 type Greeting struct {
-	FirstName            string   `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName             string   `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	FirstName string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// BCP 47 language tag (e.g. pt-BR), the grpc-accept-language metadata is used when empty
+	Locale               string   `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	Formal               bool     `protobuf:"varint,4,opt,name=formal,proto3" json:"formal,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -69,6 +102,20 @@ func (m *Greeting) GetLastName() string {
 	return ""
 }
 
+func (m *Greeting) GetLocale() string {
+	if m != nil {
+		return m.Locale
+	}
+	return ""
+}
+
+func (m *Greeting) GetFormal() bool {
+	if m != nil {
+		return m.Formal
+	}
+	return false
+}
+
 type GreetRequest struct {
 	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -148,10 +195,18 @@ func (m *GreetResponse) GetResult() string {
 }
 
 type GreetManyTimesRequest struct {
-	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// how many messages to send, defaults to 10
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// pause between messages, defaults to 1000ms
+	IntervalMs int64 `protobuf:"varint,3,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	// message template, {greeting}, {first_name}, {last_name} and {number} are replaced
+	Template string `protobuf:"bytes,4,opt,name=template,proto3" json:"template,omitempty"`
+	// keep sending messages until the client cancels, count is ignored
+	UntilCancelled       bool     `protobuf:"varint,5,opt,name=until_cancelled,json=untilCancelled,proto3" json:"until_cancelled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GreetManyTimesRequest) Reset()         { *m = GreetManyTimesRequest{} }
@@ -186,6 +241,34 @@ func (m *GreetManyTimesRequest) GetGreeting() *Greeting {
 	return nil
 }
 
+func (m *GreetManyTimesRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GreetManyTimesRequest) GetIntervalMs() int64 {
+	if m != nil {
+		return m.IntervalMs
+	}
+	return 0
+}
+
+func (m *GreetManyTimesRequest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *GreetManyTimesRequest) GetUntilCancelled() bool {
+	if m != nil {
+		return m.UntilCancelled
+	}
+	return false
+}
+
 type GreetManyTimesResponse struct {
 	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -265,7 +348,14 @@ func (m *LongGreetRequest) GetGreeting() *Greeting {
 }
 
 type LongGreetResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// combined greetings of the distinct names
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// distinct names, in the order they were received
+	Names []string `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	// greetings received
+	Count int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// greetings dropped because the name was already greeted
+	DuplicatesRemoved    int32    `protobuf:"varint,4,opt,name=duplicates_removed,json=duplicatesRemoved,proto3" json:"duplicates_removed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -303,11 +393,34 @@ func (m *LongGreetResponse) GetResult() string {
 	return ""
 }
 
+func (m *LongGreetResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *LongGreetResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *LongGreetResponse) GetDuplicatesRemoved() int32 {
+	if m != nil {
+		return m.DuplicatesRemoved
+	}
+	return 0
+}
+
 type GreetEveryoneRequest struct {
-	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// room to join, only read from the first message, defaults to "lobby"
+	Room                 string   `protobuf:"bytes,2,opt,name=room,proto3" json:"room,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GreetEveryoneRequest) Reset()         { *m = GreetEveryoneRequest{} }
@@ -342,8 +455,19 @@ func (m *GreetEveryoneRequest) GetGreeting() *Greeting {
 	return nil
 }
 
+func (m *GreetEveryoneRequest) GetRoom() string {
+	if m != nil {
+		return m.Room
+	}
+	return ""
+}
+
 type GreetEveryoneResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Result string                      `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Event  GreetEveryoneResponse_Event `protobuf:"varint,2,opt,name=event,proto3,enum=greet.GreetEveryoneResponse_Event" json:"event,omitempty"`
+	Room   string                      `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"`
+	// name of the member the event is about
+	From                 string   `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -381,6 +505,27 @@ func (m *GreetEveryoneResponse) GetResult() string {
 	return ""
 }
 
+func (m *GreetEveryoneResponse) GetEvent() GreetEveryoneResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return GreetEveryoneResponse_GREETING
+}
+
+func (m *GreetEveryoneResponse) GetRoom() string {
+	if m != nil {
+		return m.Room
+	}
+	return ""
+}
+
+func (m *GreetEveryoneResponse) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
 type GreetWithDeadLineRequest struct {
 	Greeting             *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -459,7 +604,169 @@ func (m *GreetWithDeadLineResponse) GetResult() string {
 	return ""
 }
 
+type GreetingRecord struct {
+	Greeting *Greeting `protobuf:"bytes,1,opt,name=greeting,proto3" json:"greeting,omitempty"`
+	// RPC that handled the greeting, e.g. GreetManyTimes
+	Rpc                  string               `protobuf:"bytes,2,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	Result               string               `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	GreetedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=greeted_at,json=greetedAt,proto3" json:"greeted_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GreetingRecord) Reset()         { *m = GreetingRecord{} }
+func (m *GreetingRecord) String() string { return proto.CompactTextString(m) }
+func (*GreetingRecord) ProtoMessage()    {}
+func (*GreetingRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe6f881da19a2871, []int{11}
+}
+
+func (m *GreetingRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GreetingRecord.Unmarshal(m, b)
+}
+func (m *GreetingRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GreetingRecord.Marshal(b, m, deterministic)
+}
+func (m *GreetingRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GreetingRecord.Merge(m, src)
+}
+func (m *GreetingRecord) XXX_Size() int {
+	return xxx_messageInfo_GreetingRecord.Size(m)
+}
+func (m *GreetingRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_GreetingRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GreetingRecord proto.InternalMessageInfo
+
+func (m *GreetingRecord) GetGreeting() *Greeting {
+	if m != nil {
+		return m.Greeting
+	}
+	return nil
+}
+
+func (m *GreetingRecord) GetRpc() string {
+	if m != nil {
+		return m.Rpc
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *GreetingRecord) GetGreetedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.GreetedAt
+	}
+	return nil
+}
+
+type GetGreetingHistoryRequest struct {
+	// only greetings handled at or after this time
+	Since *timestamp.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	// only greetings handled before this time
+	Until *timestamp.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	// first, last or full name of the greeted person, case insensitive
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetGreetingHistoryRequest) Reset()         { *m = GetGreetingHistoryRequest{} }
+func (m *GetGreetingHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGreetingHistoryRequest) ProtoMessage()    {}
+func (*GetGreetingHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe6f881da19a2871, []int{12}
+}
+
+func (m *GetGreetingHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGreetingHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetGreetingHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGreetingHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetGreetingHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGreetingHistoryRequest.Merge(m, src)
+}
+func (m *GetGreetingHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetGreetingHistoryRequest.Size(m)
+}
+func (m *GetGreetingHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGreetingHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGreetingHistoryRequest proto.InternalMessageInfo
+
+func (m *GetGreetingHistoryRequest) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *GetGreetingHistoryRequest) GetUntil() *timestamp.Timestamp {
+	if m != nil {
+		return m.Until
+	}
+	return nil
+}
+
+func (m *GetGreetingHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetGreetingHistoryResponse struct {
+	Record               *GreetingRecord `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetGreetingHistoryResponse) Reset()         { *m = GetGreetingHistoryResponse{} }
+func (m *GetGreetingHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGreetingHistoryResponse) ProtoMessage()    {}
+func (*GetGreetingHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fe6f881da19a2871, []int{13}
+}
+
+func (m *GetGreetingHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetGreetingHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetGreetingHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetGreetingHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetGreetingHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGreetingHistoryResponse.Merge(m, src)
+}
+func (m *GetGreetingHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetGreetingHistoryResponse.Size(m)
+}
+func (m *GetGreetingHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGreetingHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetGreetingHistoryResponse proto.InternalMessageInfo
+
+func (m *GetGreetingHistoryResponse) GetRecord() *GreetingRecord {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("greet.GreetEveryoneResponse_Event", GreetEveryoneResponse_Event_name, GreetEveryoneResponse_Event_value)
 	proto.RegisterType((*Greeting)(nil), "greet.Greeting")
 	proto.RegisterType((*GreetRequest)(nil), "greet.GreetRequest")
 	proto.RegisterType((*GreetResponse)(nil), "greet.GreetResponse")
@@ -471,36 +778,67 @@ func init() {
 	proto.RegisterType((*GreetEveryoneResponse)(nil), "greet.GreetEveryoneResponse")
 	proto.RegisterType((*GreetWithDeadLineRequest)(nil), "greet.GreetWithDeadLineRequest")
 	proto.RegisterType((*GreetWithDeadLineResponse)(nil), "greet.GreetWithDeadLineResponse")
+	proto.RegisterType((*GreetingRecord)(nil), "greet.GreetingRecord")
+	proto.RegisterType((*GetGreetingHistoryRequest)(nil), "greet.GetGreetingHistoryRequest")
+	proto.RegisterType((*GetGreetingHistoryResponse)(nil), "greet.GetGreetingHistoryResponse")
 }
 
 func init() { proto.RegisterFile("greet/greetpb/greet.proto", fileDescriptor_fe6f881da19a2871) }
 
 var fileDescriptor_fe6f881da19a2871 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4f, 0x4f, 0xf2, 0x40,
-	0x10, 0xc6, 0xdf, 0xbe, 0x06, 0xa4, 0xe3, 0x5f, 0x46, 0x44, 0x28, 0x10, 0x49, 0x2f, 0x92, 0x90,
-	0x00, 0x01, 0x6f, 0x1e, 0x4c, 0x10, 0xe5, 0x82, 0x46, 0xd1, 0x44, 0xe3, 0xc5, 0x14, 0x1d, 0x6b,
-	0x13, 0x68, 0xb1, 0x5d, 0x48, 0xf8, 0x04, 0x7e, 0x6d, 0xc3, 0xb2, 0x0b, 0xa5, 0x80, 0x4d, 0x7a,
-	0x69, 0xbb, 0xfb, 0x3c, 0xfb, 0x7b, 0xb6, 0x3b, 0x93, 0x85, 0xac, 0xe9, 0x12, 0xb1, 0x2a, 0x7f,
-	0x0e, 0x7b, 0xb3, 0x77, 0x65, 0xe8, 0x3a, 0xcc, 0xc1, 0x18, 0x1f, 0xe8, 0x37, 0x90, 0x68, 0x4f,
-	0x3f, 0x2c, 0xdb, 0xc4, 0x02, 0xc0, 0xa7, 0xe5, 0x7a, 0xec, 0xcd, 0x36, 0x06, 0x94, 0x51, 0x8a,
-	0x4a, 0x49, 0xed, 0xaa, 0x7c, 0xe6, 0xce, 0x18, 0x10, 0xe6, 0x40, 0xed, 0x1b, 0x52, 0xfd, 0xcf,
-	0xd5, 0xc4, 0x74, 0x62, 0x2a, 0xea, 0x17, 0xb0, 0xcb, 0x39, 0x5d, 0xfa, 0x1e, 0x91, 0xc7, 0xb0,
-	0x0c, 0x09, 0x53, 0x70, 0x39, 0x69, 0xa7, 0x7e, 0x50, 0x99, 0xc5, 0xcb, 0xb8, 0xee, 0xdc, 0xa0,
-	0x9f, 0xc1, 0x9e, 0x58, 0xec, 0x0d, 0x1d, 0xdb, 0x23, 0x4c, 0x43, 0xdc, 0x25, 0x6f, 0xd4, 0x67,
-	0x62, 0x17, 0x62, 0xa4, 0xb7, 0xe0, 0x98, 0x1b, 0x6f, 0x0d, 0x7b, 0xf2, 0x64, 0x0d, 0xc8, 0x8b,
-	0x14, 0x57, 0x83, 0x74, 0x90, 0x12, 0x92, 0x7b, 0x09, 0x87, 0x1d, 0xc7, 0x36, 0xa3, 0xff, 0x61,
-	0x19, 0x92, 0x3e, 0x40, 0x48, 0xda, 0x15, 0xa4, 0xb8, 0xf1, 0x7a, 0x4c, 0xee, 0xc4, 0xb1, 0x29,
-	0x52, 0x62, 0x55, 0x1c, 0xd5, 0x02, 0x12, 0x92, 0xda, 0x86, 0x0c, 0x5f, 0xf0, 0x6c, 0xb1, 0xaf,
-	0x16, 0x19, 0x1f, 0x1d, 0x2b, 0x62, 0x72, 0x03, 0xb2, 0x6b, 0x40, 0x7f, 0xa7, 0xd7, 0x7f, 0xb6,
-	0x44, 0x03, 0x3d, 0x92, 0x3b, 0xb6, 0xde, 0x09, 0xcf, 0x21, 0xc6, 0xc7, 0x78, 0xe4, 0x4f, 0x12,
-	0x1b, 0xd2, 0x52, 0xcb, 0x93, 0x33, 0xb8, 0xfe, 0x0f, 0x1f, 0x60, 0x7f, 0xb9, 0xb4, 0x98, 0xf7,
-	0x3b, 0x83, 0x7d, 0xa3, 0x15, 0x36, 0xa8, 0x12, 0x58, 0x53, 0xb0, 0x09, 0xea, 0xbc, 0x74, 0x78,
-	0x22, 0xfc, 0xc1, 0x6e, 0xd0, 0x32, 0xab, 0x82, 0x64, 0x94, 0x14, 0xbc, 0x17, 0x0d, 0x2e, 0x8b,
-	0x81, 0x39, 0x7f, 0x6e, 0xa0, 0xce, 0x5a, 0x7e, 0xbd, 0xb8, 0xe0, 0xd5, 0x14, 0x7c, 0x81, 0xe4,
-	0xca, 0x21, 0xe3, 0xa9, 0x7f, 0xe1, 0x9a, 0x3a, 0x6a, 0xc5, 0xcd, 0x06, 0x49, 0x6f, 0xaa, 0xaf,
-	0xdb, 0xe2, 0xbe, 0xe8, 0xc5, 0xf9, 0x55, 0xd1, 0xf8, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xe0, 0x9f,
-	0x71, 0xee, 0x47, 0x04, 0x00, 0x00,
+	// 830 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xc1, 0x6e, 0xdb, 0x46,
+	0x10, 0xcd, 0x8a, 0xa2, 0x42, 0x8e, 0x13, 0x47, 0xde, 0x3a, 0x29, 0xc5, 0xa4, 0xb5, 0x4b, 0x1f,
+	0x2c, 0x20, 0x88, 0x6c, 0xd8, 0x3d, 0xb4, 0xc7, 0x2a, 0x51, 0xd5, 0xb4, 0x8e, 0xd3, 0x6e, 0x05,
+	0xb4, 0x28, 0x50, 0x08, 0x2b, 0x72, 0xa5, 0x12, 0x20, 0xb9, 0x2c, 0xb9, 0x12, 0xe0, 0xab, 0x0f,
+	0x3d, 0x17, 0x3e, 0xf7, 0xd4, 0x0f, 0xe9, 0xb9, 0xd7, 0xfe, 0x44, 0xbf, 0x23, 0xe0, 0xee, 0x52,
+	0xa2, 0x64, 0xd9, 0x3a, 0xe8, 0x62, 0x73, 0x66, 0xde, 0xbc, 0x99, 0x7d, 0x3b, 0x3b, 0x10, 0xb4,
+	0x26, 0x19, 0x63, 0xe2, 0x44, 0xfe, 0x4d, 0x47, 0xea, 0x7f, 0x27, 0xcd, 0xb8, 0xe0, 0xd8, 0x94,
+	0x86, 0x7b, 0x30, 0xe1, 0x7c, 0x12, 0xb1, 0x13, 0xe9, 0x1c, 0x4d, 0xc7, 0x27, 0x22, 0x8c, 0x59,
+	0x2e, 0x68, 0x9c, 0x2a, 0x9c, 0xdb, 0x9e, 0xd1, 0x28, 0x0c, 0xa8, 0x08, 0x79, 0x72, 0xb2, 0xf8,
+	0x4c, 0x47, 0x15, 0x43, 0x21, 0xbd, 0x3f, 0x11, 0x58, 0xfd, 0x82, 0x34, 0x4c, 0x26, 0xf8, 0x18,
+	0x60, 0x1c, 0x66, 0xb9, 0x18, 0x26, 0x34, 0x66, 0x0e, 0x3a, 0x44, 0x6d, 0xbb, 0x6b, 0xdd, 0x5c,
+	0xb7, 0xea, 0x16, 0x72, 0x02, 0x62, 0xcb, 0xd8, 0x25, 0x8d, 0x19, 0x3e, 0x02, 0x3b, 0xa2, 0x25,
+	0xae, 0x26, 0x71, 0x8d, 0x9b, 0xeb, 0x56, 0xcd, 0x09, 0x88, 0x15, 0x51, 0x0d, 0xfa, 0x14, 0x1a,
+	0x11, 0xf7, 0x69, 0xc4, 0x1c, 0xa3, 0x82, 0x38, 0x22, 0xda, 0x8b, 0x9f, 0x41, 0x63, 0xcc, 0xb3,
+	0x98, 0x46, 0x4e, 0xfd, 0x10, 0xb5, 0x2d, 0xa2, 0x2d, 0xef, 0x35, 0x3c, 0x92, 0x1d, 0x11, 0xf6,
+	0xfb, 0x94, 0xe5, 0x02, 0x9f, 0x83, 0x35, 0xd1, 0x1d, 0xca, 0x9e, 0x76, 0xce, 0x9e, 0x74, 0x94,
+	0x28, 0x65, 0xe3, 0x8a, 0xda, 0x42, 0x64, 0x0e, 0xf4, 0x8e, 0xe1, 0xb1, 0x26, 0xc9, 0x53, 0x9e,
+	0xe4, 0xb2, 0x5a, 0xc6, 0xf2, 0x69, 0x24, 0xd4, 0xb9, 0x88, 0xb6, 0xbc, 0xff, 0x10, 0x3c, 0x95,
+	0xc8, 0x77, 0x34, 0xb9, 0x1a, 0x14, 0x3a, 0x6e, 0x53, 0x17, 0xef, 0x83, 0xe9, 0xf3, 0x69, 0x22,
+	0xa4, 0x2a, 0x26, 0x51, 0x06, 0x3e, 0x80, 0x9d, 0x30, 0x11, 0x2c, 0x9b, 0xd1, 0x68, 0x18, 0xe7,
+	0x52, 0x0f, 0x83, 0x40, 0xe9, 0x7a, 0x97, 0xe3, 0x23, 0xb0, 0x04, 0x8b, 0xd3, 0x88, 0x0a, 0x26,
+	0xd5, 0xb0, 0xbb, 0x0f, 0x6f, 0xae, 0x5b, 0x86, 0xf3, 0x2f, 0x22, 0xf3, 0x00, 0x3e, 0x86, 0x27,
+	0xd3, 0x44, 0x84, 0xd1, 0xd0, 0xa7, 0x89, 0xcf, 0xa2, 0x88, 0x05, 0x8e, 0x29, 0x95, 0xdb, 0x95,
+	0xee, 0xd7, 0xa5, 0xd7, 0x3b, 0x85, 0x67, 0xab, 0x47, 0xda, 0xa0, 0x42, 0x1f, 0x9a, 0x17, 0x3c,
+	0x99, 0x6c, 0xaf, 0xfb, 0x1f, 0x08, 0xf6, 0x2a, 0x4c, 0xf7, 0x97, 0x2d, 0xd4, 0x2a, 0x46, 0x28,
+	0x77, 0x6a, 0x87, 0x46, 0xdb, 0x26, 0xca, 0x58, 0x68, 0x68, 0x54, 0x35, 0x7c, 0x05, 0x38, 0x98,
+	0xa6, 0x51, 0xe8, 0x53, 0xc1, 0xf2, 0x61, 0xc6, 0x62, 0x3e, 0x63, 0x81, 0x14, 0xcb, 0x24, 0x7b,
+	0x8b, 0x08, 0x51, 0x01, 0x6f, 0x02, 0xfb, 0xb2, 0x87, 0xde, 0x8c, 0x65, 0x57, 0x3c, 0x61, 0x5b,
+	0xdd, 0xaa, 0x0b, 0xf5, 0x8c, 0xf3, 0x78, 0x65, 0xd4, 0xa5, 0xcf, 0xfb, 0xa7, 0x1c, 0xa0, 0x45,
+	0xa5, 0x0d, 0xa7, 0xfe, 0x02, 0x4c, 0x36, 0x63, 0x7a, 0x46, 0x76, 0xcf, 0xbc, 0x6a, 0xfd, 0x55,
+	0x92, 0x4e, 0xaf, 0x40, 0x12, 0x95, 0x80, 0xb1, 0xee, 0x43, 0x3e, 0x28, 0x55, 0xbf, 0xf0, 0x8d,
+	0x33, 0x1e, 0xab, 0xb1, 0x21, 0xf2, 0xdb, 0x7b, 0x09, 0xa6, 0xcc, 0xc3, 0x8f, 0xc0, 0xea, 0x93,
+	0x5e, 0x6f, 0xf0, 0xf6, 0xb2, 0xdf, 0x7c, 0x80, 0x01, 0x1a, 0xdf, 0xbe, 0x7f, 0x7b, 0xd9, 0x7b,
+	0xd3, 0x44, 0xd8, 0x82, 0xfa, 0x45, 0xef, 0xeb, 0x41, 0xb3, 0xe6, 0xbd, 0x07, 0x47, 0x96, 0xfe,
+	0x29, 0x14, 0xbf, 0xbd, 0x61, 0x34, 0xb8, 0x08, 0xb7, 0x53, 0xcb, 0x3b, 0x87, 0xd6, 0x1a, 0xc2,
+	0x0d, 0x13, 0xf8, 0x37, 0x82, 0xdd, 0x92, 0x93, 0x30, 0x9f, 0x67, 0x01, 0x7e, 0xb9, 0xb1, 0x78,
+	0xe5, 0x8a, 0x9a, 0x60, 0x64, 0xa9, 0xaf, 0x6e, 0x88, 0x14, 0x9f, 0x95, 0x4a, 0xc6, 0x92, 0xfc,
+	0x5f, 0x02, 0xc8, 0x2c, 0x16, 0x0c, 0xa9, 0x90, 0xb2, 0xed, 0x9c, 0xb9, 0x1d, 0xb5, 0x52, 0x3b,
+	0xe5, 0x4a, 0xed, 0x0c, 0xca, 0x95, 0x4a, 0x6c, 0x8d, 0xfe, 0x4a, 0x78, 0x7f, 0x21, 0x68, 0xf5,
+	0x99, 0x28, 0xcb, 0x7f, 0x13, 0xe6, 0x82, 0x67, 0x57, 0xa5, 0x58, 0xa7, 0x60, 0xe6, 0x61, 0xe2,
+	0x33, 0x07, 0x6d, 0xe4, 0x54, 0xc0, 0x22, 0x43, 0x3e, 0x5d, 0xa7, 0xb6, 0x39, 0x43, 0x02, 0xf1,
+	0x73, 0xa8, 0xcb, 0xa5, 0x6b, 0x2c, 0x2f, 0x09, 0xe9, 0xf4, 0xbe, 0x03, 0x77, 0x5d, 0x77, 0x5a,
+	0xf9, 0x57, 0x85, 0x1e, 0x85, 0xb0, 0xba, 0xbf, 0xa7, 0xab, 0x62, 0xca, 0x20, 0xd1, 0xa0, 0xb3,
+	0xff, 0x0d, 0xbd, 0x87, 0x7f, 0x64, 0xd9, 0x2c, 0xf4, 0x19, 0xfe, 0x1c, 0x4c, 0x69, 0xe3, 0x8f,
+	0xaa, 0x89, 0xfa, 0xf0, 0xee, 0xfe, 0xb2, 0x53, 0xd5, 0xf4, 0x1e, 0xe0, 0x1f, 0x60, 0x77, 0x79,
+	0x17, 0xe1, 0x17, 0x55, 0xe4, 0xea, 0xd6, 0x75, 0x3f, 0xb9, 0x23, 0x5a, 0x12, 0x9e, 0x22, 0xdc,
+	0x05, 0x7b, 0xbe, 0x62, 0xf0, 0xc7, 0x1a, 0xbf, 0xba, 0xbe, 0x5c, 0xe7, 0x76, 0xa0, 0xe4, 0x68,
+	0x23, 0xfc, 0x3d, 0x3c, 0x5e, 0x7a, 0x6f, 0xf8, 0xf9, 0xfa, 0x57, 0xa8, 0xb8, 0x5e, 0xdc, 0xf7,
+	0x44, 0x0b, 0xbe, 0x53, 0x84, 0x7f, 0x86, 0xbd, 0x5b, 0x53, 0x8f, 0x0f, 0xaa, 0x89, 0x6b, 0x1e,
+	0x98, 0x7b, 0x78, 0x37, 0x60, 0x2e, 0xe1, 0xaf, 0x80, 0x6f, 0x5f, 0x2b, 0x9e, 0x67, 0xde, 0x35,
+	0x8f, 0xee, 0x67, 0xf7, 0x20, 0x16, 0x72, 0x76, 0xed, 0x5f, 0x1e, 0xea, 0xdf, 0x1a, 0xa3, 0x86,
+	0x1c, 0xbc, 0xf3, 0x0f, 0x03, 0x00, 0xd4, 0x75, 0x50, 0x60, 0x83, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -520,11 +858,16 @@ type GreetServiceClient interface {
 	// Server Streaming
 	GreetManyTimes(ctx context.Context, in *GreetManyTimesRequest, opts ...grpc.CallOption) (GreetService_GreetManyTimesClient, error)
 	// Cliente streaming
+	// RESOURCE_EXHAUSTED when the client sends too many greetings
 	LongGreet(ctx context.Context, opts ...grpc.CallOption) (GreetService_LongGreetClient, error)
 	// BiDi Streaming
+	// every stream joins a room and receives the greetings of the other members
 	GreetEveryone(ctx context.Context, opts ...grpc.CallOption) (GreetService_GreetEveryoneClient, error)
 	// Unary with deadline
 	GreetWithDeadLine(ctx context.Context, in *GreetWithDeadLineRequest, opts ...grpc.CallOption) (*GreetWithDeadLineResponse, error)
+	// Server Streaming
+	// the greetings handled so far, oldest first
+	GetGreetingHistory(ctx context.Context, in *GetGreetingHistoryRequest, opts ...grpc.CallOption) (GreetService_GetGreetingHistoryClient, error)
 }
 
 type greetServiceClient struct {
@@ -650,6 +993,38 @@ func (c *greetServiceClient) GreetWithDeadLine(ctx context.Context, in *GreetWit
 	return out, nil
 }
 
+func (c *greetServiceClient) GetGreetingHistory(ctx context.Context, in *GetGreetingHistoryRequest, opts ...grpc.CallOption) (GreetService_GetGreetingHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GreetService_serviceDesc.Streams[3], "/greet.GreetService/GetGreetingHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &greetServiceGetGreetingHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GreetService_GetGreetingHistoryClient interface {
+	Recv() (*GetGreetingHistoryResponse, error)
+	grpc.ClientStream
+}
+
+type greetServiceGetGreetingHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *greetServiceGetGreetingHistoryClient) Recv() (*GetGreetingHistoryResponse, error) {
+	m := new(GetGreetingHistoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GreetServiceServer is the server API for GreetService service.
 type GreetServiceServer interface {
 	// Unary
@@ -657,11 +1032,16 @@ type GreetServiceServer interface {
 	// Server Streaming
 	GreetManyTimes(*GreetManyTimesRequest, GreetService_GreetManyTimesServer) error
 	// Cliente streaming
+	// RESOURCE_EXHAUSTED when the client sends too many greetings
 	LongGreet(GreetService_LongGreetServer) error
 	// BiDi Streaming
+	// every stream joins a room and receives the greetings of the other members
 	GreetEveryone(GreetService_GreetEveryoneServer) error
 	// Unary with deadline
 	GreetWithDeadLine(context.Context, *GreetWithDeadLineRequest) (*GreetWithDeadLineResponse, error)
+	// Server Streaming
+	// the greetings handled so far, oldest first
+	GetGreetingHistory(*GetGreetingHistoryRequest, GreetService_GetGreetingHistoryServer) error
 }
 
 func RegisterGreetServiceServer(s *grpc.Server, srv GreetServiceServer) {
@@ -777,6 +1157,27 @@ func _GreetService_GreetWithDeadLine_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GreetService_GetGreetingHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetGreetingHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GreetServiceServer).GetGreetingHistory(m, &greetServiceGetGreetingHistoryServer{stream})
+}
+
+type GreetService_GetGreetingHistoryServer interface {
+	Send(*GetGreetingHistoryResponse) error
+	grpc.ServerStream
+}
+
+type greetServiceGetGreetingHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *greetServiceGetGreetingHistoryServer) Send(m *GetGreetingHistoryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _GreetService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "greet.GreetService",
 	HandlerType: (*GreetServiceServer)(nil),
@@ -807,6 +1208,11 @@ var _GreetService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "GetGreetingHistory",
+			Handler:       _GreetService_GetGreetingHistory_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "greet/greetpb/greet.proto",
 }
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor rejects the calls of a client over the limit of the
+// method with RESOURCE_EXHAUSTED, telling it when to retry.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		client := l.config.Key(ctx)
+		if ok, wait := l.allow(client, info.FullMethod); !ok {
+			return nil, l.rateLimited(client, info.FullMethod, wait)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor applies the limit of the method to opening
+// streams, and rejects the streams of a client over MaxStreams.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		client := l.config.Key(ss.Context())
+		if ok, wait := l.allow(client, info.FullMethod); !ok {
+			return l.rateLimited(client, info.FullMethod, wait)
+		}
+		if !l.openStream(client) {
+			return exhausted(
+				fmt.Sprintf("Too many open streams, at most %v are allowed", l.config.MaxStreams),
+				&errdetails.QuotaFailure{
+					Violations: []*errdetails.QuotaFailure_Violation{
+						&errdetails.QuotaFailure_Violation{
+							Subject:     client,
+							Description: fmt.Sprintf("at most %v concurrent streams", l.config.MaxStreams),
+						},
+					},
+				},
+			)
+		}
+		defer l.closeStream(client)
+		return handler(srv, ss)
+	}
+}
+
+func (l *Limiter) rateLimited(client, method string, wait time.Duration) error {
+	limit := l.limitFor(method)
+	return exhausted(
+		fmt.Sprintf("Too many calls to %v, retry in %v", method, wait.Round(time.Millisecond)),
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				&errdetails.QuotaFailure_Violation{
+					Subject:     client,
+					Description: fmt.Sprintf("at most %v calls per second to %v, in bursts of %v", limit.Rate, method, limit.Burst),
+				},
+			},
+		},
+		&errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(wait),
+		},
+	)
+}
+
+func exhausted(message string, details ...proto.Message) error {
+	st := status.New(codes.ResourceExhausted, message)
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
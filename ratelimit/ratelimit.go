@@ -0,0 +1,275 @@
+// Package ratelimit limits how often every client can call each method of a
+// server with token buckets, and how many streams it can keep open.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Limit lets a client make Rate calls per second, with bursts of up to Burst calls
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) normalized() Limit {
+	if l.Burst < 1 {
+		l.Burst = 1
+	}
+	return l
+}
+
+// KeyFunc tells the clients apart
+type KeyFunc func(ctx context.Context) string
+
+// Config configures a Limiter
+type Config struct {
+	// Key tells the clients apart, PeerKey when nil
+	Key KeyFunc
+	// Default applies to the methods missing from Methods, unlimited when zero
+	Default Limit
+	// Methods are keyed by full method name ("/pkg.Service/Method") or by method name
+	Methods map[string]Limit
+	// MaxStreams caps the streams a client can have open, unlimited when 0
+	MaxStreams int
+}
+
+// Limiter keeps the token buckets and open streams of every client
+type Limiter struct {
+	config Config
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	streams   map[string]int
+	lastSweep time.Time
+	// now is time.Now, replaced by the tests
+	now func() time.Time
+}
+
+type bucketKey struct {
+	client string
+	method string
+}
+
+// bucket holds up to burst tokens, refilled at rate tokens per second
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// idle buckets are forgotten once full, checked this often
+const sweepInterval = time.Minute
+
+// New returns a Limiter for c
+func New(c Config) *Limiter {
+	if c.Key == nil {
+		c.Key = PeerKey
+	}
+	// a bucket holds at least one token
+	c.Default = c.Default.normalized()
+	methods := make(map[string]Limit, len(c.Methods))
+	for method, limit := range c.Methods {
+		methods[method] = limit.normalized()
+	}
+	c.Methods = methods
+	return &Limiter{
+		config:  c,
+		buckets: make(map[bucketKey]*bucket),
+		streams: make(map[string]int),
+		now:     time.Now,
+	}
+}
+
+// limitFor returns the limit of a full method name
+func (l *Limiter) limitFor(method string) Limit {
+	if limit, ok := l.config.Methods[method]; ok {
+		return limit
+	}
+	if limit, ok := l.config.Methods[method[strings.LastIndex(method, "/")+1:]]; ok {
+		return limit
+	}
+	return l.config.Default
+}
+
+// allow takes a token of the bucket of client for method, otherwise it
+// returns how long to wait for the next one
+func (l *Limiter) allow(client, method string) (bool, time.Duration) {
+	limit := l.limitFor(method)
+	if limit.Rate <= 0 {
+		return true, 0
+	}
+	burst := float64(limit.Burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	key := bucketKey{client: client, method: method}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * limit.Rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	return false, wait
+}
+
+// sweep forgets the buckets that had time to fill up again, they behave
+// the same as new ones
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		limit := l.limitFor(key.method)
+		full := time.Duration(float64(limit.Burst+1) / limit.Rate * float64(time.Second))
+		if now.Sub(b.last) > full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// openStream counts a new stream of client, false when it has too many
+func (l *Limiter) openStream(client string) bool {
+	if l.config.MaxStreams <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streams[client] >= l.config.MaxStreams {
+		return false
+	}
+	l.streams[client]++
+	return true
+}
+
+func (l *Limiter) closeStream(client string) {
+	if l.config.MaxStreams <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.streams[client]--
+	if l.streams[client] <= 0 {
+		delete(l.streams, client)
+	}
+}
+
+// PeerKey tells the clients apart by IP address
+func PeerKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// IdentityKey tells the clients apart by the common name of their verified
+// TLS certificate, and by IP address when they do not have one
+func IdentityKey(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			return "cn:" + info.State.VerifiedChains[0][0].Subject.CommonName
+		}
+	}
+	return PeerKey(ctx)
+}
+
+// APIKey tells the clients apart by the value of the header metadata when it
+// is one of keys, and by IP address otherwise. The other values are not
+// trusted, a client sending a new one on every call would get new buckets
+// every time.
+func APIKey(header string, keys []string) KeyFunc {
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+	return func(ctx context.Context) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(header); len(values) > 0 && known[values[0]] {
+			return "key:" + values[0]
+		}
+		return PeerKey(ctx)
+	}
+}
+
+// ParseKey returns the KeyFunc named peer, identity or api-key, apiKeys are
+// the x-api-key values told apart by api-key
+func ParseKey(name string, apiKeys []string) (KeyFunc, error) {
+	switch name {
+	case "peer":
+		return PeerKey, nil
+	case "identity":
+		return IdentityKey, nil
+	case "api-key":
+		if len(apiKeys) == 0 {
+			return nil, fmt.Errorf("the api-key rate limit key needs the API keys of the clients")
+		}
+		return APIKey("x-api-key", apiKeys), nil
+	}
+	return nil, fmt.Errorf("unknown rate limit key %q, use peer, identity or api-key", name)
+}
+
+// ParseLimit reads a limit written "rate" or "rate:burst", e.g. "5:10" for
+// 5 calls per second in bursts of 10
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.SplitN(s, ":", 2)
+	rate, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || rate < 0 {
+		return Limit{}, fmt.Errorf("invalid rate %q", parts[0])
+	}
+	limit := Limit{Rate: rate, Burst: int(rate)}
+	if len(parts) == 2 {
+		if limit.Burst, err = strconv.Atoi(parts[1]); err != nil || limit.Burst < 1 {
+			return Limit{}, fmt.Errorf("invalid burst %q", parts[1])
+		}
+	}
+	return limit, nil
+}
+
+// ParseMethodLimits reads comma separated method=limit pairs, e.g.
+// "PrimeNumberDecomposition=1:5,Sum=100"
+func ParseMethodLimits(s string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	if s == "" {
+		return limits, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid method limit %q, use method=rate:burst", pair)
+		}
+		limit, err := ParseLimit(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		limits[strings.TrimSpace(parts[0])] = limit
+	}
+	return limits, nil
+}
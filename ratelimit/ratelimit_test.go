@@ -0,0 +1,337 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/calculator.CalculatorService/Sum"
+
+// fakeClock is moved forward by the tests instead of waiting
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+// newTestLimiter returns a Limiter of c, with every call made by the same
+// client, and its clock
+func newTestLimiter(c Config) (*Limiter, *fakeClock) {
+	if c.Key == nil {
+		c.Key = func(context.Context) string { return "client" }
+	}
+	l := New(c)
+	clock := &fakeClock{t: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l.now = clock.now
+	return l, clock
+}
+
+func TestRefill(t *testing.T) {
+	l, clock := newTestLimiter(Config{Default: Limit{Rate: 2, Burst: 3}})
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		allowed bool
+		wait    time.Duration
+	}{
+		{"burst 1", 0, true, 0},
+		{"burst 2", 0, true, 0},
+		{"burst 3", 0, true, 0},
+		{"empty", 0, false, 500 * time.Millisecond},
+		{"half a token", 250 * time.Millisecond, false, 250 * time.Millisecond},
+		{"one token", 250 * time.Millisecond, true, 0},
+		{"empty again", 0, false, 500 * time.Millisecond},
+		// a long pause refills up to the burst, not more
+		{"refilled 1", time.Hour, true, 0},
+		{"refilled 2", 0, true, 0},
+		{"refilled 3", 0, true, 0},
+		{"refilled empty", 0, false, 500 * time.Millisecond},
+	}
+	for _, step := range steps {
+		clock.advance(step.advance)
+		allowed, wait := l.allow("client", testMethod)
+		if allowed != step.allowed || wait != step.wait {
+			t.Errorf("%v: allow = %v, %v, want %v, %v", step.name, allowed, wait, step.allowed, step.wait)
+		}
+	}
+}
+
+func TestMethodLimits(t *testing.T) {
+	l, _ := newTestLimiter(Config{
+		Default: Limit{Rate: 1, Burst: 1},
+		Methods: map[string]Limit{
+			"/calculator.CalculatorService/Average": {Rate: 1, Burst: 3},
+			"PrimeNumberDecomposition":              {Rate: 1, Burst: 2},
+		},
+	})
+
+	tests := []struct {
+		method string
+		calls  int
+	}{
+		{"/calculator.CalculatorService/Sum", 1},
+		{"/calculator.CalculatorService/Average", 3},
+		{"/calculator.CalculatorService/PrimeNumberDecomposition", 2},
+	}
+	for _, tt := range tests {
+		calls := 0
+		for {
+			if ok, _ := l.allow("client", tt.method); !ok {
+				break
+			}
+			calls++
+		}
+		if calls != tt.calls {
+			t.Errorf("%v: %v calls allowed, want %v", tt.method, calls, tt.calls)
+		}
+	}
+
+	// every client has its own buckets
+	if ok, _ := l.allow("other", "/calculator.CalculatorService/Sum"); !ok {
+		t.Errorf("the call of another client was not allowed")
+	}
+}
+
+func TestUnlimited(t *testing.T) {
+	l, _ := newTestLimiter(Config{})
+	for i := 0; i < 1000; i++ {
+		if ok, _ := l.allow("client", testMethod); !ok {
+			t.Fatalf("call %v was not allowed without a limit", i)
+		}
+	}
+}
+
+func TestRetryInfo(t *testing.T) {
+	l, clock := newTestLimiter(Config{Default: Limit{Rate: 4, Burst: 1}})
+	interceptor := l.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	if _, err := interceptor(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	clock.advance(100 * time.Millisecond)
+	_, err := interceptor(context.Background(), nil, info, handler)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("code = %v, want %v", st.Code(), codes.ResourceExhausted)
+	}
+
+	var retry *errdetails.RetryInfo
+	var quota *errdetails.QuotaFailure
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.RetryInfo:
+			retry = d
+		case *errdetails.QuotaFailure:
+			quota = d
+		}
+	}
+	if retry == nil {
+		t.Fatalf("no RetryInfo in %v", st.Details())
+	}
+	// a token every 250ms, 100ms went by
+	delay, err := ptypes.Duration(retry.GetRetryDelay())
+	if err != nil || delay != 150*time.Millisecond {
+		t.Errorf("retry delay = %v, %v, want %v", delay, err, 150*time.Millisecond)
+	}
+	if quota == nil || len(quota.GetViolations()) != 1 || quota.GetViolations()[0].GetSubject() != "client" {
+		t.Errorf("quota failure = %v, want a violation of client", quota)
+	}
+
+	clock.advance(150 * time.Millisecond)
+	if _, err := interceptor(context.Background(), nil, info, handler); err != nil {
+		t.Errorf("call after the retry delay: %v", err)
+	}
+}
+
+// testStream is a server stream with a context and nothing else
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestMaxStreams(t *testing.T) {
+	l, _ := newTestLimiter(Config{MaxStreams: 2})
+	interceptor := l.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/calculator.CalculatorService/PrimeNumberDecomposition"}
+	stream := &testStream{ctx: context.Background()}
+
+	// the handlers run until their channel is closed
+	open := func() (chan struct{}, chan error) {
+		release := make(chan struct{})
+		done := make(chan error, 1)
+		started := make(chan struct{})
+		go func() {
+			done <- interceptor(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		select {
+		case <-started:
+		case err := <-done:
+			done <- err
+		}
+		return release, done
+	}
+
+	first, firstDone := open()
+	second, secondDone := open()
+	third, thirdDone := open()
+	close(third)
+	if err := <-thirdDone; status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("third stream: code = %v, want %v", status.Code(err), codes.ResourceExhausted)
+	}
+
+	// closing a stream frees its slot
+	close(first)
+	if err := <-firstDone; err != nil {
+		t.Fatalf("first stream: %v", err)
+	}
+	fourth, fourthDone := open()
+	close(fourth)
+	if err := <-fourthDone; err != nil {
+		t.Errorf("stream opened after one closed: %v", err)
+	}
+
+	close(second)
+	if err := <-secondDone; err != nil {
+		t.Fatalf("second stream: %v", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.streams) != 0 {
+		t.Errorf("open streams = %v once all of them closed, want none", l.streams)
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Limit
+		wantErr bool
+	}{
+		{s: "5", want: Limit{Rate: 5, Burst: 5}},
+		{s: "5:10", want: Limit{Rate: 5, Burst: 10}},
+		{s: "0.5:2", want: Limit{Rate: 0.5, Burst: 2}},
+		{s: "0", want: Limit{}},
+		{s: "", wantErr: true},
+		{s: "fast", wantErr: true},
+		{s: "-1", wantErr: true},
+		{s: "5:0", wantErr: true},
+		{s: "5:many", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLimit(%q) error = %v, want error %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLimit(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseMethodLimits(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    map[string]Limit
+		wantErr bool
+	}{
+		{s: "", want: map[string]Limit{}},
+		{s: "Sum=100", want: map[string]Limit{"Sum": {Rate: 100, Burst: 100}}},
+		{
+			s: "PrimeNumberDecomposition=1:5, /calculator.CalculatorService/Sum=100",
+			want: map[string]Limit{
+				"PrimeNumberDecomposition":          {Rate: 1, Burst: 5},
+				"/calculator.CalculatorService/Sum": {Rate: 100, Burst: 100},
+			},
+		},
+		{s: "Sum", wantErr: true},
+		{s: "Sum=fast", wantErr: true},
+		{s: "Sum=1,Average", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMethodLimits(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMethodLimits(%q) error = %v, want error %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseMethodLimits(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	key := APIKey("x-api-key", []string{"known"})
+	from := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234},
+	})
+
+	tests := []struct {
+		name   string
+		apiKey string
+		want   string
+	}{
+		{"known key", "known", "key:known"},
+		// a made up key would get new buckets
+		{"unknown key", "random", "10.0.0.1"},
+		{"no key", "", "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		ctx := from
+		if tt.apiKey != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", tt.apiKey))
+		}
+		if got := key(ctx); got != tt.want {
+			t.Errorf("%v: key = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		apiKeys []string
+		wantErr bool
+	}{
+		{name: "peer"},
+		{name: "identity"},
+		{name: "api-key", apiKeys: []string{"known"}},
+		{name: "api-key", wantErr: true},
+		{name: "header", wantErr: true},
+	}
+	for _, tt := range tests {
+		if _, err := ParseKey(tt.name, tt.apiKeys); (err != nil) != tt.wantErr {
+			t.Errorf("ParseKey(%q, %v) error = %v, want error %v", tt.name, tt.apiKeys, err, tt.wantErr)
+		}
+	}
+}
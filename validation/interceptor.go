@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor rejects invalid requests with INVALID_ARGUMENT
+// before they reach the handler.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validates every message received from the client
+// of a streaming RPC, the stream fails on the first invalid one. The messages
+// of the full methods listed are left to their handler, which checks them one
+// by one with Check.
+func StreamServerInterceptor(unchecked ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for _, method := range unchecked {
+			if info.FullMethod == method {
+				return handler(srv, ss)
+			}
+		}
+		return handler(srv, &validatingStream{ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(m)
+}
+
+// Check returns the INVALID_ARGUMENT error the interceptors send for msg, nil
+// when it is valid.
+func Check(msg proto.Message) error {
+	return validateRequest(msg)
+}
+
+func validateRequest(req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	violations := Validate(msg)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	var descriptions []string
+	for _, violation := range violations {
+		descriptions = append(descriptions, fmt.Sprintf("%v %v", violation.GetField(), violation.GetDescription()))
+	}
+	st := status.New(
+		codes.InvalidArgument,
+		fmt.Sprintf("Invalid request: %v", strings.Join(descriptions, ", ")),
+	)
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
@@ -0,0 +1,197 @@
+// Package validation checks requests against the (validation.rules)
+// annotations declared on the proto fields.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/FernandoDevBh/grpc-go-course/validation/validationpb"
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+// fieldRules ties a struct field of a generated message to its annotation
+type fieldRules struct {
+	name  string
+	index int
+	rules *validationpb.FieldRules
+}
+
+// registry caches the rules of every message type seen so far
+var registry sync.Map // map[reflect.Type][]fieldRules
+
+// name of the field holding the paths of a partial update
+const updateMaskField = "update_mask"
+
+// Validate returns a violation for every field of msg (and of its nested
+// messages) that breaks its rules. An empty result means msg is valid.
+//
+// When msg has an update_mask field with paths, only the fields of its nested
+// messages listed in the mask are checked, the others are not updated.
+func Validate(msg proto.Message) []*errdetails.BadRequest_FieldViolation {
+	return validate(reflect.ValueOf(msg), "")
+}
+
+func validate(v reflect.Value, prefix string) []*errdetails.BadRequest_FieldViolation {
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	fields := rulesFor(v.Type())
+	mask := updateMask(v, fields)
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, f := range fields {
+		field := v.Elem().Field(f.index)
+		path := prefix + f.name
+		if f.rules != nil {
+			for _, description := range check(field, f.rules) {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       path,
+					Description: description,
+				})
+			}
+		}
+
+		// nested messages carry their own rules
+		switch {
+		case mask != nil && f.name != updateMaskField && isMessage(field.Type()):
+			for _, violation := range validate(field, path+".") {
+				if masked(mask, strings.TrimPrefix(violation.GetField(), path+".")) {
+					violations = append(violations, violation)
+				}
+			}
+		case isMessage(field.Type()):
+			violations = append(violations, validate(field, path+".")...)
+		case field.Kind() == reflect.Slice && isMessage(field.Type().Elem()):
+			for i := 0; i < field.Len(); i++ {
+				violations = append(violations, validate(field.Index(i), fmt.Sprintf("%v[%v].", path, i))...)
+			}
+		}
+	}
+	return violations
+}
+
+// updateMask returns the update_mask of a message, nil when it has none or
+// the mask is empty
+func updateMask(v reflect.Value, fields []fieldRules) *field_mask.FieldMask {
+	for _, f := range fields {
+		if f.name != updateMaskField {
+			continue
+		}
+		mask, ok := v.Elem().Field(f.index).Interface().(*field_mask.FieldMask)
+		if ok && len(mask.GetPaths()) > 0 {
+			return mask
+		}
+	}
+	return nil
+}
+
+// masked tells if the mask covers the field path
+func masked(mask *field_mask.FieldMask, path string) bool {
+	for _, p := range mask.GetPaths() {
+		if p == "*" || path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func rulesFor(t reflect.Type) []fieldRules {
+	if cached, ok := registry.Load(t); ok {
+		return cached.([]fieldRules)
+	}
+
+	var rules []fieldRules
+	msg, ok := reflect.Zero(t).Interface().(descriptor.Message)
+	if ok {
+		_, md := descriptor.ForMessage(msg)
+		props := proto.GetProperties(t.Elem())
+		for i, prop := range props.Prop {
+			if prop.OrigName == "" {
+				// XXX_ fields
+				continue
+			}
+			f := fieldRules{
+				name:  prop.OrigName,
+				index: i,
+			}
+			for _, fd := range md.GetField() {
+				if fd.GetName() != prop.OrigName || fd.GetOptions() == nil {
+					continue
+				}
+				if ext, err := proto.GetExtension(fd.GetOptions(), validationpb.E_Rules); err == nil {
+					f.rules = ext.(*validationpb.FieldRules)
+				}
+			}
+			rules = append(rules, f)
+		}
+	}
+
+	registry.Store(t, rules)
+	return rules
+}
+
+func isMessage(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Implements(reflect.TypeOf((*proto.Message)(nil)).Elem())
+}
+
+// check returns the description of every rule the field value breaks
+func check(v reflect.Value, rules *validationpb.FieldRules) []string {
+	var descriptions []string
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		length := uint32(utf8.RuneCountInString(s))
+		if rules.GetRequired() && strings.TrimSpace(s) == "" {
+			descriptions = append(descriptions, "must not be empty")
+		}
+		if rules.MinLen != nil && length < rules.GetMinLen() {
+			descriptions = append(descriptions, fmt.Sprintf("must be at least %v characters long", rules.GetMinLen()))
+		}
+		if rules.MaxLen != nil && length > rules.GetMaxLen() {
+			descriptions = append(descriptions, fmt.Sprintf("must be at most %v characters long", rules.GetMaxLen()))
+		}
+
+	case reflect.Ptr:
+		if rules.GetRequired() && v.IsNil() {
+			descriptions = append(descriptions, "is required")
+		}
+
+	case reflect.Slice:
+		if rules.GetRequired() && v.Len() == 0 {
+			descriptions = append(descriptions, "must not be empty")
+		}
+
+	case reflect.Int32, reflect.Int64:
+		descriptions = append(descriptions, checkRange(float64(v.Int()), rules)...)
+
+	case reflect.Uint32, reflect.Uint64:
+		descriptions = append(descriptions, checkRange(float64(v.Uint()), rules)...)
+
+	case reflect.Float32, reflect.Float64:
+		descriptions = append(descriptions, checkRange(v.Float(), rules)...)
+	}
+	return descriptions
+}
+
+func checkRange(n float64, rules *validationpb.FieldRules) []string {
+	var descriptions []string
+	if rules.Gt != nil && !(n > rules.GetGt()) {
+		descriptions = append(descriptions, fmt.Sprintf("must be greater than %v", rules.GetGt()))
+	}
+	if rules.Gte != nil && !(n >= rules.GetGte()) {
+		descriptions = append(descriptions, fmt.Sprintf("must be greater than or equal to %v", rules.GetGte()))
+	}
+	if rules.Lt != nil && !(n < rules.GetLt()) {
+		descriptions = append(descriptions, fmt.Sprintf("must be less than %v", rules.GetLt()))
+	}
+	if rules.Lte != nil && !(n <= rules.GetLte()) {
+		descriptions = append(descriptions, fmt.Sprintf("must be less than or equal to %v", rules.GetLte()))
+	}
+	return descriptions
+}
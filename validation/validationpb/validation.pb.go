@@ -0,0 +1,153 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: validation/validationpb/validation.proto
+
+package validationpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	descriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// FieldRules are checked by the validation interceptor before a request reaches the handlers
+type FieldRules struct {
+	// strings must not be blank, messages must be set and repeated fields must not be empty
+	Required *bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	// strings length, in characters
+	MinLen *uint32 `protobuf:"varint,2,opt,name=min_len,json=minLen" json:"min_len,omitempty"`
+	MaxLen *uint32 `protobuf:"varint,3,opt,name=max_len,json=maxLen" json:"max_len,omitempty"`
+	// numbers range
+	Gt                   *float64 `protobuf:"fixed64,4,opt,name=gt" json:"gt,omitempty"`
+	Gte                  *float64 `protobuf:"fixed64,5,opt,name=gte" json:"gte,omitempty"`
+	Lt                   *float64 `protobuf:"fixed64,6,opt,name=lt" json:"lt,omitempty"`
+	Lte                  *float64 `protobuf:"fixed64,7,opt,name=lte" json:"lte,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FieldRules) Reset()         { *m = FieldRules{} }
+func (m *FieldRules) String() string { return proto.CompactTextString(m) }
+func (*FieldRules) ProtoMessage()    {}
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1af83e733fb0084b, []int{0}
+}
+
+func (m *FieldRules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldRules.Unmarshal(m, b)
+}
+func (m *FieldRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldRules.Marshal(b, m, deterministic)
+}
+func (m *FieldRules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldRules.Merge(m, src)
+}
+func (m *FieldRules) XXX_Size() int {
+	return xxx_messageInfo_FieldRules.Size(m)
+}
+func (m *FieldRules) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldRules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldRules proto.InternalMessageInfo
+
+func (m *FieldRules) GetRequired() bool {
+	if m != nil && m.Required != nil {
+		return *m.Required
+	}
+	return false
+}
+
+func (m *FieldRules) GetMinLen() uint32 {
+	if m != nil && m.MinLen != nil {
+		return *m.MinLen
+	}
+	return 0
+}
+
+func (m *FieldRules) GetMaxLen() uint32 {
+	if m != nil && m.MaxLen != nil {
+		return *m.MaxLen
+	}
+	return 0
+}
+
+func (m *FieldRules) GetGt() float64 {
+	if m != nil && m.Gt != nil {
+		return *m.Gt
+	}
+	return 0
+}
+
+func (m *FieldRules) GetGte() float64 {
+	if m != nil && m.Gte != nil {
+		return *m.Gte
+	}
+	return 0
+}
+
+func (m *FieldRules) GetLt() float64 {
+	if m != nil && m.Lt != nil {
+		return *m.Lt
+	}
+	return 0
+}
+
+func (m *FieldRules) GetLte() float64 {
+	if m != nil && m.Lte != nil {
+		return *m.Lte
+	}
+	return 0
+}
+
+var E_Rules = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*FieldRules)(nil),
+	Field:         51234,
+	Name:          "validation.rules",
+	Tag:           "bytes,51234,opt,name=rules",
+	Filename:      "validation/validationpb/validation.proto",
+}
+
+func init() {
+	proto.RegisterType((*FieldRules)(nil), "validation.FieldRules")
+	proto.RegisterExtension(E_Rules)
+}
+
+func init() {
+	proto.RegisterFile("validation/validationpb/validation.proto", fileDescriptor_1af83e733fb0084b)
+}
+
+var fileDescriptor_1af83e733fb0084b = []byte{
+	// 274 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0xcd, 0x4a, 0xc4, 0x30,
+	0x10, 0xc7, 0x49, 0xd7, 0xfd, 0x20, 0xa2, 0x48, 0x0f, 0x1a, 0x16, 0x84, 0xe2, 0xa9, 0x97, 0x4d,
+	0xc0, 0xa3, 0x17, 0x71, 0x91, 0x3d, 0x29, 0x42, 0x8f, 0x5e, 0xa4, 0x1f, 0x63, 0x36, 0x90, 0x66,
+	0x6a, 0x3a, 0x5d, 0xf6, 0x31, 0x7c, 0x02, 0x0f, 0x3e, 0xa9, 0x34, 0x55, 0xbb, 0x97, 0xbd, 0xcd,
+	0xcc, 0xef, 0x47, 0x32, 0xff, 0xe1, 0xe9, 0x2e, 0xb7, 0xa6, 0xca, 0xc9, 0xa0, 0x53, 0x63, 0xd9,
+	0x14, 0x07, 0x8d, 0x6c, 0x3c, 0x12, 0xc6, 0x7c, 0x9c, 0x2c, 0x13, 0x8d, 0xa8, 0x2d, 0xa8, 0x40,
+	0x8a, 0xee, 0x5d, 0x55, 0xd0, 0x96, 0xde, 0x34, 0x84, 0x7e, 0xb0, 0x6f, 0xbe, 0x18, 0xe7, 0x1b,
+	0x03, 0xb6, 0xca, 0x3a, 0x0b, 0x6d, 0xbc, 0xe4, 0x0b, 0x0f, 0x1f, 0x9d, 0xf1, 0x50, 0x09, 0x96,
+	0xb0, 0x74, 0x91, 0xfd, 0xf7, 0xf1, 0x15, 0x9f, 0xd7, 0xc6, 0xbd, 0x59, 0x70, 0x22, 0x4a, 0x58,
+	0x7a, 0x96, 0xcd, 0x6a, 0xe3, 0x9e, 0xc0, 0x05, 0x90, 0xef, 0x03, 0x98, 0xfc, 0x82, 0x7c, 0xdf,
+	0x83, 0x73, 0x1e, 0x69, 0x12, 0x27, 0x09, 0x4b, 0x59, 0x16, 0x69, 0x8a, 0x2f, 0xf8, 0x44, 0x13,
+	0x88, 0x69, 0x18, 0xf4, 0x65, 0x6f, 0x58, 0x12, 0xb3, 0xc1, 0xb0, 0xc1, 0xb0, 0x04, 0x62, 0x3e,
+	0x18, 0x96, 0xe0, 0xee, 0x99, 0x4f, 0x7d, 0x58, 0xed, 0x5a, 0x0e, 0x61, 0xe4, 0x5f, 0x18, 0x19,
+	0xf6, 0x7e, 0x69, 0xfa, 0xa4, 0xad, 0xf8, 0xfe, 0xec, 0xff, 0x3e, 0xbd, 0xbd, 0x94, 0x07, 0x17,
+	0x19, 0x93, 0x65, 0xc3, 0x2b, 0xeb, 0x87, 0xd7, 0x7b, 0x6d, 0x68, 0xdb, 0x15, 0xb2, 0xc4, 0x5a,
+	0x6d, 0xc0, 0xbb, 0xdc, 0x55, 0xf8, 0x08, 0xbb, 0xf5, 0x56, 0x69, 0xdf, 0x94, 0x2b, 0x8d, 0xab,
+	0x12, 0x3b, 0xdf, 0x82, 0x3a, 0x72, 0xf1, 0x9f, 0x01, 0x00, 0x02, 0xf8, 0xb2, 0x06, 0x8b, 0x01,
+	0x00, 0x00,
+}